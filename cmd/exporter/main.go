@@ -16,6 +16,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
@@ -26,60 +27,144 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
-	"github.com/dirsigler/hackerone-exporter/internal/config"
-	"github.com/dirsigler/hackerone-exporter/internal/exporter"
+	"github.com/dirsigler/hackerone-exporter/internal/h1fake"
 	"github.com/dirsigler/hackerone-exporter/internal/handler"
+	"github.com/dirsigler/hackerone-exporter/pkg/config"
+	"github.com/dirsigler/hackerone-exporter/pkg/exporter"
+	versioncollector "github.com/prometheus/client_golang/prometheus/collectors/version"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/common/version"
+	"github.com/prometheus/exporter-toolkit/web"
 	"github.com/urfave/cli/v3"
 )
 
 func main() {
 	cmd := &cli.Command{
-		Name:  "hackerone-exporter",
-		Usage: "Export HackerOne metrics to Prometheus",
-		Flags: config.CLIFlags(),
+		Name:    "hackerone-exporter",
+		Usage:   "Export HackerOne metrics to Prometheus",
+		Version: version.Version,
+		Flags:   append(config.CLIFlags(), exporter.CollectorFlags()...),
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			// Load configuration
-			cfg := config.New(cmd)
+			cfg, err := config.New(cmd)
+			if err != nil {
+				return err
+			}
 
 			// Setup logger
 			logger := cfg.SetupLogger()
 
+			// Fail early on an invalid web configuration instead of on the
+			// first request
+			if err := web.Validate(cfg.WebConfigFile); err != nil {
+				return fmt.Errorf("invalid web configuration file: %w", err)
+			}
+
 			logger.Info("Starting HackerOne Prometheus Exporter",
+				slog.String("version", version.Info()),
 				slog.Int("port", int(cfg.Port)),
 				slog.String("log_level", cfg.LogLevel),
-				slog.String("organization_id", cfg.OrgID),
+				slog.Duration("scrape_interval", cfg.ScrapeInterval),
 			)
 
+			// Setup context for graceful shutdown
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			// Demo mode scrapes an in-process fake of the HackerOne API
+			var demoURL string
+			if cfg.Demo {
+				demoURL, err = h1fake.New(h1fake.Seed(time.Now())).Start(ctx)
+				if err != nil {
+					return fmt.Errorf("starting fake HackerOne API: %w", err)
+				}
+				cfg.APIURL = demoURL
+				logger.Warn("Demo mode enabled, serving generated data", slog.String("api_url", demoURL))
+			}
+
 			// Create exporter
-			exp := exporter.New(cfg, logger)
+			exp, err := exporter.New(cfg, logger)
+			if err != nil {
+				return err
+			}
+			logger.Info("Enabled collectors",
+				slog.Any("organizations", exp.Organizations()),
+				slog.Any("collectors", exp.Collectors()))
+
+			// Reload re-reads flags, environment and the configuration file.
+			// The HTTP listener and log level are kept until a restart.
+			reload := func() error {
+				newCfg, err := config.New(cmd)
+				if err == nil && newCfg.Demo != cfg.Demo {
+					err = fmt.Errorf("demo mode can only be toggled with a restart")
+				}
+				if err == nil {
+					if newCfg.Demo {
+						newCfg.APIURL = demoURL
+					}
+					err = exp.Reload(newCfg)
+				}
+				if err != nil {
+					logger.Error("Configuration reload failed", slog.String("error", err.Error()))
+					return err
+				}
+				if newCfg.Port != cfg.Port || newCfg.LogLevel != cfg.LogLevel {
+					logger.Warn("Port and log level changes take effect after a restart")
+				}
+				return nil
+			}
 
 			// Create a new registry and register the exporter
 			prometheus.MustRegister(exp)
+			prometheus.MustRegister(versioncollector.NewCollector("hackerone_exporter"))
 
 			// Setup HTTP server
 			mux := http.NewServeMux()
-			mux.HandleFunc("/", handler.IndexHandler)
+			mux.Handle("/", handler.IndexHandler(exp.Status))
 			mux.HandleFunc("/healthz", handler.HealthHandler)
+			mux.HandleFunc("/livez", handler.HealthHandler)
+			mux.Handle("/readyz", handler.ReadinessHandler(exp.Status))
 			mux.Handle("/metrics", promhttp.Handler())
+			mux.Handle("/-/reload", handler.ReloadHandler(reload))
+			mux.Handle("/probe", handler.ProbeHandler(exp.Probe))
 
 			server := &http.Server{
 				Addr:    ":" + strconv.Itoa(int(cfg.Port)),
 				Handler: mux,
 			}
 
-			// Setup context for graceful shutdown
-			_, cancel := context.WithCancel(context.Background())
-			defer cancel()
+			// Start background scraping of the HackerOne API
+			go exp.Run(ctx)
 
 			// Handle shutdown signals
 			sigChan := make(chan os.Signal, 1)
 			signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
-			// Start HTTP server
+			// Reload the configuration on SIGHUP
+			hupChan := make(chan os.Signal, 1)
+			signal.Notify(hupChan, syscall.SIGHUP)
+			go func() {
+				for {
+					select {
+					case <-hupChan:
+						logger.Info("SIGHUP received, reloading configuration")
+						//nolint:errcheck
+						reload()
+					case <-ctx.Done():
+						return
+					}
+				}
+			}()
+
+			// Start HTTP server, with TLS and basic authentication if
+			// configured in the web configuration file
 			go func() {
 				logger.Info("HTTP server starting", slog.Int("port", int(cfg.Port)))
-				if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				flags := &web.FlagConfig{
+					WebListenAddresses: &[]string{server.Addr},
+					WebConfigFile:      &cfg.WebConfigFile,
+				}
+				if err := web.ListenAndServe(server, flags, logger); err != nil && err != http.ErrServerClosed {
 					logger.Error("HTTP server error", slog.String("error", err.Error()))
 				}
 			}()
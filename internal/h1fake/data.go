@@ -0,0 +1,259 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package h1fake
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// Data is the content served by the fake API
+type Data struct {
+	// Assets are returned for every organization ID
+	Assets   []Asset
+	Programs []Program
+}
+
+// Asset is an asset of the organization
+type Asset struct {
+	ID         string
+	AssetType  string
+	DomainName string
+	State      string
+	CreatedAt  time.Time
+}
+
+// Program is a program and everything the API returns for it
+type Program struct {
+	ID               string
+	Handle           string
+	Balance          float64
+	Reports          []Report
+	Reporters        []Reporter
+	Weaknesses       []Weakness
+	StructuredScopes []StructuredScope
+	Invitations      []Invitation
+}
+
+// Report is a report submitted to a program. Nil timestamps mark the stages
+// the report has not reached yet.
+type Report struct {
+	ID                     string
+	Title                  string
+	State                  string
+	Severity               string
+	Weakness               Weakness
+	AssetType              string
+	CreatedAt              time.Time
+	FirstProgramActivityAt *time.Time
+	TriagedAt              *time.Time
+	ClosedAt               *time.Time
+	BountyAwardedAt        *time.Time
+	Bounties               []Bounty
+}
+
+// Bounty is a bounty awarded for a report
+type Bounty struct {
+	ID          string
+	Amount      float64
+	BonusAmount float64
+	Currency    string
+}
+
+// Reporter is a hacker who submitted reports to a program
+type Reporter struct {
+	ID         string
+	Username   string
+	Reputation int
+	Signal     float64
+	Impact     float64
+}
+
+// Weakness is a weakness type of a program
+type Weakness struct {
+	ID         string
+	Name       string
+	ExternalID string
+}
+
+// StructuredScope is an asset in the scope of a program
+type StructuredScope struct {
+	ID                string
+	AssetIdentifier   string
+	AssetType         string
+	EligibleForBounty bool
+	MaxSeverity       string
+}
+
+// Invitation is an invitation of a hacker to a program
+type Invitation struct {
+	ID    string
+	State string
+}
+
+var (
+	seedHandles     = []string{"acme", "globex", "initech"}
+	seedAssetTypes  = []string{"URL", "WILDCARD", "CIDR", "GOOGLE_PLAY_APP_ID", "APPLE_STORE_APP_ID", "SOURCE_CODE"}
+	seedSeverities  = []string{"none", "low", "medium", "high", "critical"}
+	seedInvitations = []string{"accepted", "pending", "rejected", "expired", "cancelled"}
+	seedOpenStates  = []string{"new", "pending-program-review", "triaged", "needs-more-info"}
+	seedClosedState = []string{"resolved", "duplicate", "informative", "not-applicable", "spam"}
+	seedWeaknesses  = []Weakness{
+		{Name: "Cross-site Scripting (XSS) - Reflected", ExternalID: "cwe-79"},
+		{Name: "SQL Injection", ExternalID: "cwe-89"},
+		{Name: "Server-Side Request Forgery (SSRF)", ExternalID: "cwe-918"},
+		{Name: "Improper Access Control - Generic", ExternalID: "cwe-284"},
+		{Name: "Information Disclosure", ExternalID: "cwe-200"},
+		{Name: "Cross-Site Request Forgery (CSRF)", ExternalID: "cwe-352"},
+	}
+)
+
+// Minimal returns a small data set for tests: the given number of assets,
+// named <n>.example.com, and the program acme with ID 1 and a single weakness
+func Minimal(assets int) *Data {
+	data := &Data{
+		Programs: []Program{{
+			ID:         "1",
+			Handle:     "acme",
+			Weaknesses: []Weakness{{ID: "10", Name: "Stored XSS", ExternalID: "cwe-79"}},
+		}},
+	}
+	for i := range assets {
+		data.Assets = append(data.Assets, Asset{
+			ID:         fmt.Sprint(i + 1),
+			AssetType:  "domain",
+			DomainName: fmt.Sprintf("%d.example.com", i+1),
+			State:      "confirmed",
+		})
+	}
+	return data
+}
+
+// Seed generates a realistic data set relative to now. The same now always
+// yields the same data.
+func Seed(now time.Time) *Data {
+	r := rand.New(rand.NewPCG(1, 2))
+	data := &Data{}
+
+	for i := range 15 {
+		data.Assets = append(data.Assets, Asset{
+			ID:         fmt.Sprint(100 + i),
+			AssetType:  pick(r, seedAssetTypes),
+			DomainName: fmt.Sprintf("asset-%d.example.com", i),
+			State:      "confirmed",
+			CreatedAt:  now.Add(-time.Duration(r.IntN(365*24)) * time.Hour),
+		})
+	}
+
+	for i, handle := range seedHandles {
+		p := Program{
+			ID:      fmt.Sprint(i + 1),
+			Handle:  handle,
+			Balance: float64(r.IntN(5000000)) / 100,
+		}
+
+		for j, w := range seedWeaknesses {
+			w.ID = fmt.Sprintf("%d%02d", i+1, j)
+			p.Weaknesses = append(p.Weaknesses, w)
+		}
+
+		for j := range 8 {
+			assetType := pick(r, seedAssetTypes)
+			p.StructuredScopes = append(p.StructuredScopes, StructuredScope{
+				ID:                fmt.Sprintf("%d%02d", i+1, j),
+				AssetIdentifier:   fmt.Sprintf("%s-%d.%s.example.com", assetType, j, handle),
+				AssetType:         assetType,
+				EligibleForBounty: r.IntN(4) != 0,
+				MaxSeverity:       pick(r, seedSeverities[1:]),
+			})
+		}
+
+		for j := range 10 + r.IntN(20) {
+			p.Reporters = append(p.Reporters, Reporter{
+				ID:         fmt.Sprintf("%d%03d", i+1, j),
+				Username:   fmt.Sprintf("hacker%d", j),
+				Reputation: r.IntN(5000),
+				Signal:     float64(r.IntN(700)) / 100,
+				Impact:     float64(r.IntN(5000)) / 100,
+			})
+		}
+
+		for j := range 20 + r.IntN(30) {
+			p.Invitations = append(p.Invitations, Invitation{
+				ID:    fmt.Sprintf("%d%03d", i+1, j),
+				State: pick(r, seedInvitations),
+			})
+		}
+
+		for j := range 40 + r.IntN(80) {
+			p.Reports = append(p.Reports, seedReport(r, now, fmt.Sprintf("%d%04d", i+1, j), p.Weaknesses))
+		}
+
+		data.Programs = append(data.Programs, p)
+	}
+	return data
+}
+
+// seedReport generates a report that progressed through its lifecycle at a
+// random pace
+func seedReport(r *rand.Rand, now time.Time, id string, weaknesses []Weakness) Report {
+	created := now.Add(-time.Duration(r.IntN(180*24*60)) * time.Minute)
+	report := Report{
+		ID:        id,
+		Title:     fmt.Sprintf("Report %s", id),
+		State:     pick(r, seedOpenStates),
+		Severity:  pick(r, seedSeverities),
+		Weakness:  pick(r, weaknesses),
+		AssetType: pick(r, seedAssetTypes),
+		CreatedAt: created,
+	}
+
+	// after returns a point in time up to hours after t, or nil when that
+	// is still in the future
+	after := func(t time.Time, hours int) *time.Time {
+		next := t.Add(time.Duration(1+r.IntN(hours*60)) * time.Minute)
+		if next.After(now) {
+			return nil
+		}
+		return &next
+	}
+
+	if report.FirstProgramActivityAt = after(created, 72); report.FirstProgramActivityAt == nil || report.State == "new" {
+		return report
+	}
+	if report.TriagedAt = after(*report.FirstProgramActivityAt, 7*24); report.TriagedAt == nil || r.IntN(2) == 0 {
+		return report
+	}
+	if report.ClosedAt = after(*report.TriagedAt, 30*24); report.ClosedAt == nil {
+		return report
+	}
+	report.State = pick(r, seedClosedState)
+
+	if report.State == "resolved" && report.Severity != "none" && r.IntN(3) != 0 {
+		report.BountyAwardedAt = report.ClosedAt
+		report.Bounties = append(report.Bounties, Bounty{
+			ID:          id,
+			Amount:      float64(50 * (1 + r.IntN(100))),
+			BonusAmount: float64(25 * r.IntN(4)),
+			Currency:    "USD",
+		})
+	}
+	return report
+}
+
+func pick[T any](r *rand.Rand, values []T) T {
+	return values[r.IntN(len(values))]
+}
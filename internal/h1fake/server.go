@@ -0,0 +1,445 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package h1fake implements a fake of the HackerOne API endpoints used by
+// the exporter. It serves seeded data with JSON:API pagination and can be
+// told to fail individual endpoints, which makes it usable both with
+// httptest.NewServer and as the backend of the exporter's demo mode.
+package h1fake
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultPageSize = 25
+	maxPageSize     = 100
+)
+
+// Server is a fake HackerOne API. It implements http.Handler.
+type Server struct {
+	mux *http.ServeMux
+
+	mu       sync.Mutex
+	data     *Data
+	username string
+	password string
+	failures map[string]int
+	requests map[string]int
+}
+
+// New creates a fake API serving data. Any credentials are accepted until
+// SetCredentials is called.
+func New(data *Data) *Server {
+	s := &Server{
+		mux:      http.NewServeMux(),
+		data:     data,
+		failures: make(map[string]int),
+		requests: make(map[string]int),
+	}
+
+	s.mux.HandleFunc("GET /v1/me/programs", s.programs)
+	s.mux.HandleFunc("GET /v1/organizations/{id}/assets", s.assets)
+	s.mux.HandleFunc("GET /v1/reports", s.reports)
+	s.mux.HandleFunc("GET /v1/programs/{id}/hacker_invitations", s.invitations)
+	s.mux.HandleFunc("GET /v1/programs/{id}/weaknesses", s.weaknesses)
+	s.mux.HandleFunc("GET /v1/programs/{id}/structured_scopes", s.structuredScopes)
+	s.mux.HandleFunc("GET /v1/programs/{id}/reporters", s.reporters)
+	s.mux.HandleFunc("GET /v1/programs/{id}/billing/balance", s.balance)
+	return s
+}
+
+// SetData replaces the served data
+func (s *Server) SetData(data *Data) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data = data
+}
+
+// SetCredentials makes the fake reject requests that do not authenticate
+// with username and password
+func (s *Server) SetCredentials(username, password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.username, s.password = username, password
+}
+
+// Fail makes every request to the endpoint at path respond with status until
+// Fail is called again with status 0
+func (s *Server) Fail(path string, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if status == 0 {
+		delete(s.failures, path)
+		return
+	}
+	s.failures[path] = status
+}
+
+// Requests returns how many requests were made to the endpoint at path
+func (s *Server) Requests(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[path]
+}
+
+// Start serves the fake API on a random local port until ctx is cancelled
+// and returns its base URL
+func (s *Server) Start(ctx context.Context) (string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+
+	server := &http.Server{Handler: s, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		//nolint:errcheck
+		server.Serve(listener)
+	}()
+	go func() {
+		<-ctx.Done()
+		//nolint:errcheck
+		server.Close()
+	}()
+
+	return "http://" + listener.Addr().String(), nil
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests[r.URL.Path]++
+	status := s.failures[r.URL.Path]
+	username, password := s.username, s.password
+	s.mu.Unlock()
+
+	if username != "" || password != "" {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != username || pass != password {
+			writeError(w, http.StatusUnauthorized, "Invalid API credentials")
+			return
+		}
+	}
+
+	if status != 0 {
+		writeError(w, status, "Failure injected by the fake HackerOne API")
+		return
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) programs(w http.ResponseWriter, r *http.Request) {
+	var resources []resource
+	for _, p := range s.snapshot().Programs {
+		resources = append(resources, resource{
+			ID:         p.ID,
+			Type:       "program",
+			Attributes: map[string]any{"handle": p.Handle},
+		})
+	}
+	writePage(w, r, resources)
+}
+
+func (s *Server) assets(w http.ResponseWriter, r *http.Request) {
+	var resources []resource
+	for _, a := range s.snapshot().Assets {
+		resources = append(resources, resource{
+			ID:   a.ID,
+			Type: "asset",
+			Attributes: map[string]any{
+				"asset_type":  a.AssetType,
+				"domain_name": a.DomainName,
+				"state":       a.State,
+				"created_at":  a.CreatedAt,
+			},
+		})
+	}
+	writePage(w, r, resources)
+}
+
+func (s *Server) reports(w http.ResponseWriter, r *http.Request) {
+	handles := r.URL.Query()["filter[program][]"]
+
+	var resources []resource
+	for _, p := range s.snapshot().Programs {
+		if !slices.Contains(handles, p.Handle) {
+			continue
+		}
+		for _, report := range p.Reports {
+			resources = append(resources, reportResource(p, report))
+		}
+	}
+	writePage(w, r, resources)
+}
+
+func (s *Server) invitations(w http.ResponseWriter, r *http.Request) {
+	s.programResources(w, r, func(p *Program) (resources []resource) {
+		for _, i := range p.Invitations {
+			resources = append(resources, resource{
+				ID:         i.ID,
+				Type:       "invitation",
+				Attributes: map[string]any{"state": i.State},
+			})
+		}
+		return resources
+	})
+}
+
+func (s *Server) weaknesses(w http.ResponseWriter, r *http.Request) {
+	s.programResources(w, r, func(p *Program) (resources []resource) {
+		for _, weakness := range p.Weaknesses {
+			resources = append(resources, weaknessResource(weakness))
+		}
+		return resources
+	})
+}
+
+func (s *Server) structuredScopes(w http.ResponseWriter, r *http.Request) {
+	s.programResources(w, r, func(p *Program) (resources []resource) {
+		for _, scope := range p.StructuredScopes {
+			resources = append(resources, resource{
+				ID:   scope.ID,
+				Type: "structured-scope",
+				Attributes: map[string]any{
+					"asset_identifier":    scope.AssetIdentifier,
+					"asset_type":          scope.AssetType,
+					"eligible_for_bounty": scope.EligibleForBounty,
+					"max_severity":        scope.MaxSeverity,
+				},
+			})
+		}
+		return resources
+	})
+}
+
+func (s *Server) reporters(w http.ResponseWriter, r *http.Request) {
+	s.programResources(w, r, func(p *Program) (resources []resource) {
+		for _, reporter := range p.Reporters {
+			resources = append(resources, resource{
+				ID:   reporter.ID,
+				Type: "user",
+				Attributes: map[string]any{
+					"username":   reporter.Username,
+					"reputation": reporter.Reputation,
+					"signal":     reporter.Signal,
+					"impact":     reporter.Impact,
+				},
+			})
+		}
+		return resources
+	})
+}
+
+func (s *Server) balance(w http.ResponseWriter, r *http.Request) {
+	p := s.program(r.PathValue("id"))
+	if p == nil {
+		writeError(w, http.StatusNotFound, "Program not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data": resource{
+			ID:         p.ID,
+			Type:       "program-balance",
+			Attributes: map[string]any{"balance": strconv.FormatFloat(p.Balance, 'f', 2, 64)},
+		},
+	})
+}
+
+// programResources serves a paginated collection of the program whose ID is
+// in the request path
+func (s *Server) programResources(w http.ResponseWriter, r *http.Request, resources func(*Program) []resource) {
+	p := s.program(r.PathValue("id"))
+	if p == nil {
+		writeError(w, http.StatusNotFound, "Program not found")
+		return
+	}
+	writePage(w, r, resources(p))
+}
+
+func (s *Server) snapshot() *Data {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.data
+}
+
+func (s *Server) program(id string) *Program {
+	data := s.snapshot()
+	for i := range data.Programs {
+		if data.Programs[i].ID == id {
+			return &data.Programs[i]
+		}
+	}
+	return nil
+}
+
+// resource is a JSON:API resource object
+type resource struct {
+	ID            string         `json:"id"`
+	Type          string         `json:"type"`
+	Attributes    map[string]any `json:"attributes"`
+	Relationships map[string]any `json:"relationships,omitempty"`
+}
+
+// relationship wraps resource linkage in a JSON:API relationship object
+func relationship(data any) map[string]any {
+	return map[string]any{"data": data}
+}
+
+func reportResource(p Program, report Report) resource {
+	var bounties []resource
+	for _, b := range report.Bounties {
+		bounties = append(bounties, resource{
+			ID:   b.ID,
+			Type: "bounty",
+			Attributes: map[string]any{
+				"amount":               strconv.FormatFloat(b.Amount, 'f', 2, 64),
+				"bonus_amount":         strconv.FormatFloat(b.BonusAmount, 'f', 2, 64),
+				"awarded_amount":       strconv.FormatFloat(b.Amount, 'f', 2, 64),
+				"awarded_bonus_amount": strconv.FormatFloat(b.BonusAmount, 'f', 2, 64),
+				"awarded_currency":     b.Currency,
+			},
+		})
+	}
+
+	return resource{
+		ID:   report.ID,
+		Type: "report",
+		Attributes: map[string]any{
+			"title":                     report.Title,
+			"state":                     report.State,
+			"created_at":                report.CreatedAt,
+			"submitted_at":              report.CreatedAt,
+			"first_program_activity_at": report.FirstProgramActivityAt,
+			"triaged_at":                report.TriagedAt,
+			"closed_at":                 report.ClosedAt,
+			"bounty_awarded_at":         report.BountyAwardedAt,
+		},
+		Relationships: map[string]any{
+			"program": relationship(resource{
+				ID:         p.ID,
+				Type:       "program",
+				Attributes: map[string]any{"handle": p.Handle},
+			}),
+			"severity": relationship(resource{
+				ID:         report.ID,
+				Type:       "severity",
+				Attributes: map[string]any{"rating": report.Severity},
+			}),
+			"weakness": relationship(weaknessResource(report.Weakness)),
+			"structured_scope": relationship(resource{
+				ID:         report.ID,
+				Type:       "structured-scope",
+				Attributes: map[string]any{"asset_type": report.AssetType},
+			}),
+			"bounties": relationship(bounties),
+		},
+	}
+}
+
+func weaknessResource(w Weakness) resource {
+	return resource{
+		ID:   w.ID,
+		Type: "weakness",
+		Attributes: map[string]any{
+			"name":        w.Name,
+			"external_id": w.ExternalID,
+		},
+	}
+}
+
+// writePage writes the page of resources selected by the page[number] and
+// page[size] query parameters, linking to the next page if there is one
+func writePage(w http.ResponseWriter, r *http.Request, resources []resource) {
+	query := r.URL.Query()
+	number, err := pageParameter(query, "page[number]", 1)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid page[number]")
+		return
+	}
+	size, err := pageParameter(query, "page[size]", defaultPageSize)
+	if err != nil || size > maxPageSize {
+		writeError(w, http.StatusBadRequest, "Invalid page[size]")
+		return
+	}
+
+	start := min((number-1)*size, len(resources))
+	end := min(start+size, len(resources))
+
+	links := map[string]string{"self": pageLink(r, number, size)}
+	if end < len(resources) {
+		links["next"] = pageLink(r, number+1, size)
+	}
+
+	page := resources[start:end]
+	if page == nil {
+		page = []resource{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"data": page, "links": links})
+}
+
+func pageParameter(query url.Values, name string, fallback int) (int, error) {
+	value := query.Get(name)
+	if value == "" {
+		return fallback, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err == nil && n < 1 {
+		err = errors.New("page parameters must be positive")
+	}
+	return n, err
+}
+
+// pageLink returns the absolute URL of the request with another page
+func pageLink(r *http.Request, number, size int) string {
+	query := r.URL.Query()
+	query.Set("page[number]", strconv.Itoa(number))
+	query.Set("page[size]", strconv.Itoa(size))
+
+	u := url.URL{Scheme: "http", Host: r.Host, Path: r.URL.Path, RawQuery: query.Encode()}
+	if r.TLS != nil {
+		u.Scheme = "https"
+	}
+	return u.String()
+}
+
+// writeError writes a JSON:API error document like the ones of the
+// HackerOne API
+func writeError(w http.ResponseWriter, status int, detail string) {
+	writeJSON(w, status, map[string]any{
+		"errors": []map[string]any{{
+			"status": status,
+			"title":  http.StatusText(status),
+			"detail": detail,
+		}},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	//nolint:errcheck
+	json.NewEncoder(w).Encode(v)
+}
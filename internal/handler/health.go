@@ -16,7 +16,8 @@ package handler
 
 import "net/http"
 
-// HealthHandler provides a health check endpoint
+// HealthHandler provides a liveness check endpoint. It succeeds as long as the
+// process serves HTTP requests, regardless of the HackerOne API.
 func HealthHandler(w http.ResponseWriter, r *http.Request) {
 	msg := `{"status": "ok"}`
 
@@ -14,26 +14,84 @@
 
 package handler
 
-import "net/http"
+import (
+	"html/template"
+	"net/http"
 
-// IndexHandler provides an index page with reference links
-func IndexHandler(w http.ResponseWriter, r *http.Request) {
-	msg := `
-	<html>
-		<head>
-    		<meta charset="UTF-8">
-			<meta name="viewport" content="width=device-width, initial-scale=1.0">
-			<title>HackerOne Exporter</title>
-		</head>
-		<body>
-			<h1>HackerOne Exporter</h1>
-			<p><a href="/metrics">Metrics</a></p>
-			<p><a href="/health">Healthcheck</a></p>
-		</body>
-	</html>
-			`
+	"github.com/dirsigler/hackerone-exporter/pkg/exporter"
+	"github.com/prometheus/common/version"
+)
 
-	w.WriteHeader(http.StatusOK)
-	//nolint:errcheck
-	w.Write([]byte(msg))
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+	<head>
+		<meta charset="UTF-8">
+		<meta name="viewport" content="width=device-width, initial-scale=1.0">
+		<title>HackerOne Exporter</title>
+		<style>
+			body { font-family: sans-serif; margin: 2em; }
+			table { border-collapse: collapse; margin-bottom: 1em; }
+			th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; }
+			.ok { color: #2e7d32; }
+			.failed { color: #c62828; }
+		</style>
+	</head>
+	<body>
+		<h1>HackerOne Exporter</h1>
+		<p>Version {{ .Version }}</p>
+		<p>
+			<a href="/metrics">Metrics</a> |
+			<a href="/livez">Liveness</a> |
+			<a href="/readyz">Readiness</a> |
+			<a href="/probe">Probe</a>
+		</p>
+
+		<h2>Status</h2>
+		{{ if .Status.Ready }}<p class="ok">Ready</p>{{ else }}<p class="failed">Not ready</p>{{ end }}
+		<p>Enabled collectors: {{ range $i, $c := .Status.Collectors }}{{ if $i }}, {{ end }}{{ $c }}{{ else }}none{{ end }}</p>
+
+		{{ range .Status.Organizations }}
+		<h2>Organization {{ .ID }}</h2>
+		<table>
+			<tr><th>Up</th><td>{{ if .Up }}<span class="ok">yes</span>{{ else }}<span class="failed">no</span>{{ end }}</td></tr>
+			<tr><th>Last scrape</th><td>{{ with .LastScrape }}{{ .Format "2006-01-02 15:04:05 MST" }}{{ else }}never{{ end }}</td></tr>
+			<tr><th>Last successful scrape</th><td>{{ with .LastSuccessfulScrape }}{{ .Format "2006-01-02 15:04:05 MST" }}{{ else }}never{{ end }}</td></tr>
+			<tr><th>Error</th><td>{{ with .Error }}<span class="failed">{{ . }}</span>{{ else }}-{{ end }}</td></tr>
+			<tr><th>API requests</th><td>{{ .APIRequests }}</td></tr>
+			<tr><th>API errors</th><td>{{ .APIErrors }}</td></tr>
+			<tr><th>API throttled</th><td>{{ .APIThrottled }}</td></tr>
+		</table>
+		<table>
+			<tr><th>Collector</th><th>Success</th><th>Duration</th><th>Last error</th></tr>
+			{{ range .Collectors }}
+			<tr>
+				<td>{{ .Name }}</td>
+				<td>{{ if .Success }}<span class="ok">yes</span>{{ else }}<span class="failed">no</span>{{ end }}</td>
+				<td>{{ printf "%.3fs" .DurationSeconds }}</td>
+				<td>{{ with .LastError }}{{ . }}{{ else }}-{{ end }}</td>
+			</tr>
+			{{ else }}
+			<tr><td colspan="4">No scrape has finished yet</td></tr>
+			{{ end }}
+		</table>
+		{{ end }}
+	</body>
+</html>
+`))
+
+// IndexHandler renders a status page with the configured organizations, the
+// enabled collectors and the outcome of the most recent scrape
+func IndexHandler(status func() exporter.Status) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		//nolint:errcheck
+		indexTemplate.Execute(w, struct {
+			Version string
+			Status  exporter.Status
+		}{
+			Version: version.Info(),
+			Status:  status(),
+		})
+	}
 }
@@ -0,0 +1,94 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	// defaultProbeTimeout applies when Prometheus does not announce its
+	// scrape timeout
+	defaultProbeTimeout = 120 * time.Second
+
+	// probeTimeoutOffset leaves time to send the response before Prometheus
+	// gives up on the scrape
+	probeTimeoutOffset = 500 * time.Millisecond
+)
+
+// ProbeFunc scrapes the organization target with the named module
+type ProbeFunc func(ctx context.Context, target, module string) (*prometheus.Registry, error)
+
+// ProbeHandler serves /probe?target=<org-id>&module=<name> requests in the
+// style of the blackbox_exporter. The module defaults to "default".
+func ProbeHandler(probe ProbeFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := r.URL.Query()
+
+		target := params.Get("target")
+		if target == "" {
+			http.Error(w, "Target parameter is missing", http.StatusBadRequest)
+			return
+		}
+
+		module := params.Get("module")
+		if module == "" {
+			module = "default"
+		}
+
+		timeout, err := probeTimeout(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+
+		registry, err := probe(ctx, target, module)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		promhttp.HandlerFor(registry, promhttp.HandlerOpts{}).ServeHTTP(w, r)
+	}
+}
+
+// probeTimeout derives the probe timeout from the scrape timeout Prometheus
+// sends along with every request
+func probeTimeout(r *http.Request) (time.Duration, error) {
+	header := r.Header.Get("X-Prometheus-Scrape-Timeout-Seconds")
+	if header == "" {
+		return defaultProbeTimeout, nil
+	}
+
+	seconds, err := strconv.ParseFloat(header, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse timeout from Prometheus header: %w", err)
+	}
+
+	timeout := time.Duration(seconds * float64(time.Second))
+	if timeout > probeTimeoutOffset {
+		timeout -= probeTimeoutOffset
+	}
+	return timeout, nil
+}
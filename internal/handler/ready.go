@@ -0,0 +1,41 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/dirsigler/hackerone-exporter/pkg/exporter"
+)
+
+// ReadinessHandler reports whether the exporter serves fresh HackerOne data.
+// It responds with 503 Service Unavailable until the first successful scrape
+// and whenever the last successful scrape is too old.
+func ReadinessHandler(status func() exporter.Status) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		current := status()
+
+		code := http.StatusOK
+		if !current.Ready {
+			code = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		//nolint:errcheck
+		json.NewEncoder(w).Encode(current)
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package client implements the HackerOne API client of the exporter and the
+// HackerOneAPI interface it is scraped through.
+package client
+
+import (
+	"context"
+
+	"github.com/dirsigler/hackerone-exporter/pkg/types"
+)
+
+// HackerOneAPI is the part of the HackerOne API the exporter scrapes.
+// HackerOneClient implements it against the real API; alternative
+// implementations can serve fakes, recordings or cached responses.
+type HackerOneAPI interface {
+	GetAssets(ctx context.Context, orgID string) (*types.Assets, error)
+	GetAllReports(ctx context.Context, programHandle string) (*types.Reports, error)
+	GetPrograms(ctx context.Context) (*types.Programs, error)
+	GetInvitedHackers(ctx context.Context, programID string) (*types.InvitedHackers, error)
+	GetWeaknesses(ctx context.Context, programID string) (*types.Weaknesses, error)
+	GetStructuredScopes(ctx context.Context, programID string) (*types.StructuredScopes, error)
+	GetReporters(ctx context.Context, programID string) (*types.Reporters, error)
+	GetProgramBalance(ctx context.Context, programID string) (*types.ProgramBalance, error)
+}
+
+var _ HackerOneAPI = (*HackerOneClient)(nil)
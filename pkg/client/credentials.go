@@ -0,0 +1,79 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// credential is a secret given either inline or as a file. Files are re-read
+// whenever their modification time or size changes, so rotated secrets such
+// as Kubernetes secret mounts are picked up without a restart.
+type credential struct {
+	value  string
+	path   string
+	logger *slog.Logger
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+}
+
+func newCredential(value, path string, logger *slog.Logger) *credential {
+	return &credential{value: value, path: path, logger: logger}
+}
+
+// get returns the current value of the credential. When a changed file
+// cannot be read, the previous value is kept until the next attempt.
+func (c *credential) get() (string, error) {
+	if c.path == "" {
+		return c.value, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	info, err := os.Stat(c.path)
+	if err == nil && info.ModTime().Equal(c.modTime) && info.Size() == c.size {
+		return c.value, nil
+	}
+
+	var content []byte
+	if err == nil {
+		content, err = os.ReadFile(c.path)
+	}
+	if err != nil {
+		if c.modTime.IsZero() {
+			return "", fmt.Errorf("reading credentials file: %w", err)
+		}
+		c.logger.Warn("Keeping previous credentials",
+			slog.String("file", c.path),
+			slog.String("error", err.Error()))
+		return c.value, nil
+	}
+
+	if !c.modTime.IsZero() {
+		c.logger.Info("Credentials file changed, using new credentials", slog.String("file", c.path))
+	}
+	c.value = strings.TrimSpace(string(content))
+	c.modTime = info.ModTime()
+	c.size = info.Size()
+	return c.value, nil
+}
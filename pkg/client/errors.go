@@ -0,0 +1,168 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxErrorBodySize caps how much of an error response is read
+const maxErrorBodySize = 1 << 20
+
+// ErrorObject is an entry of the `errors` array of a JSON:API error response
+type ErrorObject struct {
+	Title  string `json:"title"`
+	Detail string `json:"detail"`
+}
+
+// ResponseError is returned for API responses with an unexpected status. The
+// more specific errors below embed it, use errors.As to tell them apart. They
+// unwrap to their ResponseError, so errors.As with a *ResponseError matches
+// every error of an API response.
+type ResponseError struct {
+	Endpoint   string
+	StatusCode int
+	// Title and Detail are taken from the first entry of Errors
+	Title  string
+	Detail string
+	Errors []ErrorObject
+}
+
+// Error implements error
+func (e *ResponseError) Error() string {
+	msg := fmt.Sprintf("API request failed with status %d for endpoint %s", e.StatusCode, e.Endpoint)
+
+	var details []string
+	for _, obj := range e.Errors {
+		switch {
+		case obj.Title != "" && obj.Detail != "":
+			details = append(details, obj.Title+": "+obj.Detail)
+		case obj.Title != "":
+			details = append(details, obj.Title)
+		case obj.Detail != "":
+			details = append(details, obj.Detail)
+		}
+	}
+	if len(details) > 0 {
+		msg += ": " + strings.Join(details, "; ")
+	}
+	return msg
+}
+
+// AuthError is returned when the API rejects the credentials (401)
+type AuthError struct{ ResponseError }
+
+// Unwrap returns the embedded ResponseError
+func (e *AuthError) Unwrap() error { return &e.ResponseError }
+
+// ForbiddenError is returned when the credentials lack permissions for the
+// requested resource (403)
+type ForbiddenError struct{ ResponseError }
+
+// Unwrap returns the embedded ResponseError
+func (e *ForbiddenError) Unwrap() error { return &e.ResponseError }
+
+// NotFoundError is returned when the requested resource does not exist (404)
+type NotFoundError struct{ ResponseError }
+
+// Unwrap returns the embedded ResponseError
+func (e *NotFoundError) Unwrap() error { return &e.ResponseError }
+
+// RateLimitError is returned when requests are still throttled after all
+// retries (429)
+type RateLimitError struct {
+	ResponseError
+	// RetryAfter is taken from the Retry-After header, zero if it is missing
+	RetryAfter time.Duration
+}
+
+// Unwrap returns the embedded ResponseError
+func (e *RateLimitError) Unwrap() error { return &e.ResponseError }
+
+// ServerError is returned when the API fails to handle the request (5xx)
+type ServerError struct{ ResponseError }
+
+// Unwrap returns the embedded ResponseError
+func (e *ServerError) Unwrap() error { return &e.ResponseError }
+
+// newResponseError decodes the JSON:API error document of resp into the
+// error matching its status code
+func newResponseError(endpoint string, resp *http.Response) error {
+	base := ResponseError{Endpoint: endpoint, StatusCode: resp.StatusCode}
+
+	// The body is optional, responses of proxies are not JSON:API documents
+	var document struct {
+		Errors []ErrorObject `json:"errors"`
+	}
+	if body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize)); err == nil {
+		if json.Unmarshal(body, &document) == nil && len(document.Errors) > 0 {
+			base.Errors = document.Errors
+			base.Title = document.Errors[0].Title
+			base.Detail = document.Errors[0].Detail
+		}
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return &AuthError{base}
+	case resp.StatusCode == http.StatusForbidden:
+		return &ForbiddenError{base}
+	case resp.StatusCode == http.StatusNotFound:
+		return &NotFoundError{base}
+	case resp.StatusCode == http.StatusTooManyRequests:
+		retryAfter, _ := parseRetryAfter(resp.Header.Get("Retry-After"))
+		return &RateLimitError{ResponseError: base, RetryAfter: retryAfter}
+	case resp.StatusCode >= 500:
+		return &ServerError{base}
+	default:
+		return &base
+	}
+}
+
+// errorClass returns the hackerone_api_errors_total class of an error
+// returned by makeRequest. Requests given up because the scrape timed out or
+// was cancelled are told apart from requests the network failed.
+func errorClass(err error) string {
+	var netErr net.Error
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) ||
+		(errors.As(err, &netErr) && netErr.Timeout()) {
+		return "timeout"
+	}
+
+	switch err.(type) {
+	case *AuthError:
+		return "auth"
+	case *ForbiddenError:
+		return "forbidden"
+	case *NotFoundError:
+		return "not_found"
+	case *RateLimitError:
+		return "rate_limit"
+	case *ServerError:
+		return "server"
+	case *ResponseError:
+		return "client"
+	default:
+		return "network"
+	}
+}
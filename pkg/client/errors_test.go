@@ -0,0 +1,144 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/dirsigler/hackerone-exporter/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestNewResponseError(t *testing.T) {
+	body := `{"errors":[{"title":"Invalid credentials","detail":"The token was revoked"}]}`
+
+	tests := []struct {
+		status int
+		class  string
+		// is reports whether err is of the error type expected for status
+		is func(err error) bool
+	}{
+		{http.StatusUnauthorized, "auth", func(err error) bool { var target *AuthError; return errors.As(err, &target) }},
+		{http.StatusForbidden, "forbidden", func(err error) bool { var target *ForbiddenError; return errors.As(err, &target) }},
+		{http.StatusNotFound, "not_found", func(err error) bool { var target *NotFoundError; return errors.As(err, &target) }},
+		{http.StatusTooManyRequests, "rate_limit", func(err error) bool { var target *RateLimitError; return errors.As(err, &target) }},
+		{http.StatusInternalServerError, "server", func(err error) bool { var target *ServerError; return errors.As(err, &target) }},
+		{http.StatusBadGateway, "server", func(err error) bool { var target *ServerError; return errors.As(err, &target) }},
+		{http.StatusBadRequest, "client", func(err error) bool { var target *ResponseError; return errors.As(err, &target) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: tt.status,
+				Header:     http.Header{"Retry-After": []string{"7"}},
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}
+			err := fmt.Errorf("getting programs: %w", newResponseError("/v1/me/programs", resp))
+
+			if !tt.is(err) {
+				t.Errorf("errors.As did not find the error type of status %d in %v", tt.status, err)
+			}
+			if got := errorClass(errors.Unwrap(err)); got != tt.class {
+				t.Errorf("errorClass() = %q, want %q", got, tt.class)
+			}
+
+			var responseErr *ResponseError
+			if !errors.As(err, &responseErr) {
+				t.Fatalf("errors.As(%v, *ResponseError) = false, want true", err)
+			}
+			if responseErr.StatusCode != tt.status {
+				t.Errorf("StatusCode = %d, want %d", responseErr.StatusCode, tt.status)
+			}
+			if responseErr.Endpoint != "/v1/me/programs" {
+				t.Errorf("Endpoint = %q, want %q", responseErr.Endpoint, "/v1/me/programs")
+			}
+			if responseErr.Title != "Invalid credentials" || responseErr.Detail != "The token was revoked" {
+				t.Errorf("Title, Detail = %q, %q, want the first error object", responseErr.Title, responseErr.Detail)
+			}
+		})
+	}
+}
+
+func TestRateLimitErrorRetryAfter(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"7"}},
+		Body:       io.NopCloser(strings.NewReader("Too Many Requests")),
+	}
+
+	var rateLimitErr *RateLimitError
+	if !errors.As(newResponseError("/v1/me/programs", resp), &rateLimitErr) {
+		t.Fatal("errors.As(*RateLimitError) = false, want true")
+	}
+	if rateLimitErr.RetryAfter != 7*time.Second {
+		t.Errorf("RetryAfter = %v, want %v", rateLimitErr.RetryAfter, 7*time.Second)
+	}
+	if len(rateLimitErr.Errors) != 0 {
+		t.Errorf("Errors = %v, want none for a body that is no JSON:API document", rateLimitErr.Errors)
+	}
+}
+
+func TestErrorClass(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"cancelled", &url.Error{Op: "Get", URL: "/v1/me/programs", Err: context.Canceled}, "timeout"},
+		{"deadline exceeded", fmt.Errorf("making request: %w", context.DeadlineExceeded), "timeout"},
+		{"connection refused", &url.Error{Op: "Get", URL: "/v1/me/programs", Err: syscall.ECONNREFUSED}, "network"},
+		{"server error", &ServerError{ResponseError{StatusCode: http.StatusBadGateway}}, "server"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorClass(tt.err); got != tt.want {
+				t.Errorf("errorClass(%v) = %q, want %q", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimitedRequestsCountAsTimeout(t *testing.T) {
+	m := metrics.New("42", nil, metrics.NewInstrumentation())
+	c, _ := newTestClient(t, Options{Metrics: m, RateLimit: 0.001, Burst: 1})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if _, err := c.GetPrograms(ctx); err != nil {
+		t.Fatalf("GetPrograms() error = %v", err)
+	}
+	// The next request would only be allowed after the deadline
+	if _, err := c.GetPrograms(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("GetPrograms() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	if got := testutil.ToFloat64(m.APIErrors.WithLabelValues("/v1/me/programs", "timeout")); got != 1 {
+		t.Errorf("timeout errors = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(m.APIErrors.WithLabelValues("/v1/me/programs", "network")); got != 0 {
+		t.Errorf("network errors = %v, want 0", got)
+	}
+}
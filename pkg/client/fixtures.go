@@ -0,0 +1,187 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// redacted replaces sensitive values in recorded fixtures
+const redacted = "REDACTED"
+
+// redactedAttributes are the attributes removed from recorded fixtures. They
+// hold report contents and personal data, none of which end up in metrics.
+var redactedAttributes = map[string]bool{
+	"title":                     true,
+	"vulnerability_information": true,
+	"email":                     true,
+	"bio":                       true,
+	"website":                   true,
+	"location":                  true,
+	"expiring_url":              true,
+	"file_name":                 true,
+	"profile_picture":           true,
+}
+
+// fixturePath returns the file of the fixture recorded for endpoint. Fixtures
+// are keyed by the endpoint's path and filters and the page number, so that
+// they can be replayed with any page size as long as the content of every
+// page stays the same.
+func fixturePath(dir, endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("parsing endpoint %q: %w", endpoint, err)
+	}
+
+	query := u.Query()
+	page := query.Get("page[number]")
+	if page == "" {
+		page = "1"
+	}
+	query.Del("page[number]")
+	query.Del("page[size]")
+
+	parts := fileNameFields(u.Path)
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, value := range query[key] {
+			parts = append(parts, fileNameFields(key+" "+value)...)
+		}
+	}
+
+	name := fmt.Sprintf("%s.page-%s.json", strings.Join(parts, "-"), strings.Join(fileNameFields(page), "-"))
+	return filepath.Join(dir, name), nil
+}
+
+// fileNameFields splits s into the characters that are safe in file names
+func fileNameFields(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '.'
+	})
+}
+
+// record writes the sanitized response body of endpoint to the record
+// directory
+func (c *HackerOneClient) record(endpoint string, body []byte) error {
+	path, err := fixturePath(c.recordDir, endpoint)
+	if err != nil {
+		return err
+	}
+
+	sanitized, err := sanitize(body)
+	if err != nil {
+		return fmt.Errorf("sanitizing response of %s: %w", endpoint, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, sanitized, 0o644)
+}
+
+// replay reads the response of endpoint from the replay directory
+func (c *HackerOneClient) replay(endpoint string) ([]byte, error) {
+	path, err := fixturePath(c.replayDir, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("no recorded response for endpoint %s in %s", endpoint, c.replayDir)
+	}
+	return body, err
+}
+
+// sanitize redacts sensitive attributes and the real names of users from a
+// JSON:API response and indents it for readability
+func sanitize(body []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+	redact(document)
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func redact(value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		// Usernames are kept as they label per-user metrics
+		if v["type"] == "user" {
+			if attributes, ok := v["attributes"].(map[string]any); ok {
+				if _, ok := attributes["name"]; ok {
+					attributes["name"] = redacted
+				}
+			}
+		}
+
+		for key, child := range v {
+			if redactedAttributes[key] {
+				v[key] = redactStrings(child)
+				continue
+			}
+			redact(child)
+		}
+	case []any:
+		for _, child := range v {
+			redact(child)
+		}
+	}
+}
+
+// redactStrings replaces every string within value. Objects such as
+// profile_picture keep their shape so that fixtures still decode into their
+// types.
+func redactStrings(value any) any {
+	switch v := value.(type) {
+	case string:
+		return redacted
+	case map[string]any:
+		for key, child := range v {
+			v[key] = redactStrings(child)
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = redactStrings(child)
+		}
+	}
+	return value
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dirsigler/hackerone-exporter/pkg/types"
+)
+
+// user is a user resource carrying every kind of personal data the API returns
+const user = `{
+	"id": "7",
+	"type": "user",
+	"attributes": {
+		"username": "alice",
+		"name": "Alice Liddell",
+		"bio": "Down the rabbit hole",
+		"website": "https://alice.example.com",
+		"location": "Wonderland",
+		"reputation": 1337,
+		"profile_picture": {
+			"62x62": "https://profile-photos.example.com/alice-62.png",
+			"82x82": "https://profile-photos.example.com/alice-82.png",
+			"110x110": "https://profile-photos.example.com/alice-110.png",
+			"260x260": "https://profile-photos.example.com/alice-260.png"
+		}
+	}
+}`
+
+func TestSanitizeRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		// decode unmarshals the sanitized body and returns the user it holds
+		decode func(body []byte) (types.User, error)
+	}{
+		{
+			name: "reporters",
+			body: `{"data": [` + user + `]}`,
+			decode: func(body []byte) (types.User, error) {
+				var reporters types.Reporters
+				err := json.Unmarshal(body, &reporters)
+				if err != nil || len(reporters.Data) != 1 {
+					return types.User{}, err
+				}
+				return reporters.Data[0], nil
+			},
+		},
+		{
+			name: "invited hackers",
+			body: `{"data": [{"id": "3", "type": "invitation", "attributes": {"state": "accepted"}, "relationships": {
+				"recipient": {"data": ` + user + `}
+			}}]}`,
+			decode: func(body []byte) (types.User, error) {
+				var invitations types.InvitedHackers
+				err := json.Unmarshal(body, &invitations)
+				if err != nil || len(invitations.Data) != 1 {
+					return types.User{}, err
+				}
+				return invitations.Data[0].Relationships.Recipient.Data, nil
+			},
+		},
+		{
+			name: "reports with included reporters",
+			body: `{
+				"data": [{"id": "1", "type": "report", "attributes": {
+					"title": "Stored XSS in profile",
+					"vulnerability_information": "Steps to reproduce"
+				}, "relationships": {"reporter": {"data": {"id": "7", "type": "user"}}}}],
+				"included": [` + user + `]
+			}`,
+			decode: func(body []byte) (types.User, error) {
+				var reports types.Reports
+				err := json.Unmarshal(body, &reports)
+				if err != nil || len(reports.Data) != 1 {
+					return types.User{}, err
+				}
+				if title := reports.Data[0].Attributes.Title; title != redacted {
+					t.Errorf("title = %q, want %q", title, redacted)
+				}
+				return reports.Data[0].Relationships.Reporter.Data, nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sanitized, err := sanitize([]byte(tt.body))
+			if err != nil {
+				t.Fatalf("sanitize() error = %v", err)
+			}
+			for _, secret := range []string{"Alice Liddell", "rabbit hole", "alice.example.com", "Wonderland", "profile-photos", "Stored XSS", "Steps to reproduce"} {
+				if strings.Contains(string(sanitized), secret) {
+					t.Errorf("sanitized fixture contains %q", secret)
+				}
+			}
+
+			got, err := tt.decode(sanitized)
+			if err != nil {
+				t.Fatalf("decoding the sanitized fixture: %v", err)
+			}
+			if got.Attributes.Username != "alice" || got.Attributes.Reputation != 1337 {
+				t.Errorf("username, reputation = %q, %d, want them to be kept", got.Attributes.Username, got.Attributes.Reputation)
+			}
+			if got.Attributes.ProfilePicture.Size62 != redacted {
+				t.Errorf("profile picture = %q, want %q", got.Attributes.ProfilePicture.Size62, redacted)
+			}
+		})
+	}
+}
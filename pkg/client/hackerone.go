@@ -0,0 +1,435 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/dirsigler/hackerone-exporter/pkg/metrics"
+	"github.com/dirsigler/hackerone-exporter/pkg/types"
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// HackerOneClient handles API interactions with HackerOne
+type HackerOneClient struct {
+	username *credential
+	password *credential
+	baseURL  string
+	pageSize int
+	maxPages int
+
+	// recordDir and replayDir hold the fixtures of recorded responses
+	recordDir string
+	replayDir string
+
+	client  *retryablehttp.Client
+	metrics *metrics.Metrics
+	logger  *slog.Logger
+}
+
+// Options configures a HackerOneClient
+type Options struct {
+	Username string
+	Password string
+	// UsernameFile and PasswordFile replace Username and Password when set.
+	// The files are re-read whenever they change.
+	UsernameFile string
+	PasswordFile string
+	BaseURL      string
+	// PageSize is sent as page[size] on every collection request
+	PageSize int
+	// MaxPages caps how many pages are followed for a single collection
+	MaxPages int
+	// RateLimit is the maximum number of requests per second, 0 disables it
+	RateLimit float64
+	// Burst is the number of requests allowed to exceed RateLimit at once
+	Burst int
+	// Limiter replaces RateLimit and Burst with a Limiter shared with other
+	// clients using the same credentials
+	Limiter *Limiter
+	// Metrics receives request, latency and throttling measurements. They
+	// are discarded if Metrics is nil.
+	Metrics *metrics.Metrics
+	// RecordDir receives a sanitized copy of every successful response
+	RecordDir string
+	// ReplayDir replaces the HackerOne API with the responses recorded there
+	ReplayDir string
+}
+
+const (
+	defaultPageSize = 100
+	defaultMaxPages = 500
+)
+
+// New creates a new HackerOne API client
+func New(opts Options, logger *slog.Logger) *HackerOneClient {
+	if opts.PageSize <= 0 {
+		opts.PageSize = defaultPageSize
+	}
+	if opts.MaxPages <= 0 {
+		opts.MaxPages = defaultMaxPages
+	}
+
+	if opts.Metrics == nil {
+		opts.Metrics = metrics.New("", nil, metrics.NewInstrumentation())
+	}
+	if opts.Limiter == nil {
+		opts.Limiter = NewLimiter(opts.RateLimit, opts.Burst)
+	}
+
+	retryClient := retryablehttp.NewClient()
+	retryClient.HTTPClient.Timeout = 30 * time.Second
+	retryClient.HTTPClient.Transport = &transport{
+		next:    retryClient.HTTPClient.Transport,
+		limiter: opts.Limiter,
+		metrics: opts.Metrics,
+		logger:  logger,
+	}
+	retryClient.Backoff = backoff
+	// Hand the last response to makeRequest once retries are exhausted so
+	// that the error document of the API can be decoded
+	retryClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
+	retryClient.Logger = nil
+
+	return &HackerOneClient{
+		username:  newCredential(opts.Username, opts.UsernameFile, logger),
+		password:  newCredential(opts.Password, opts.PasswordFile, logger),
+		baseURL:   strings.TrimSuffix(opts.BaseURL, "/"),
+		pageSize:  opts.PageSize,
+		maxPages:  opts.MaxPages,
+		recordDir: opts.RecordDir,
+		replayDir: opts.ReplayDir,
+		client:    retryClient,
+		metrics:   opts.Metrics,
+		logger:    logger,
+	}
+}
+
+// makeRequest performs authenticated HTTP requests to HackerOne API
+func (c *HackerOneClient) makeRequest(ctx context.Context, endpoint string, result interface{}) error {
+	if c.replayDir != "" {
+		body, err := c.replay(endpoint)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(body, result); err != nil {
+			return fmt.Errorf("decoding recorded response: %w", err)
+		}
+		return nil
+	}
+
+	url := fmt.Sprintf("%s%s", c.baseURL, endpoint)
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "hackerone-exporter - https://github.com/dirsigler/hackerone-exporter/1.0")
+	username, err := c.username.get()
+	if err != nil {
+		return fmt.Errorf("getting API username: %w", err)
+	}
+	password, err := c.password.get()
+	if err != nil {
+		return fmt.Errorf("getting API password: %w", err)
+	}
+	req.SetBasicAuth(username, password)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		err = fmt.Errorf("making request: %w", err)
+		c.metrics.APIErrors.WithLabelValues(endpointLabel(req.URL.Path), errorClass(err)).Inc()
+		return err
+	}
+	//nolint:errcheck
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		err := newResponseError(endpoint, resp)
+		c.metrics.APIErrors.WithLabelValues(endpointLabel(req.URL.Path), errorClass(err)).Inc()
+		return err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading response: %w", err)
+	}
+
+	if c.recordDir != "" {
+		if err := c.record(endpoint, body); err != nil {
+			return fmt.Errorf("recording response: %w", err)
+		}
+	}
+
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+
+	return nil
+}
+
+// paginate walks a JSON:API collection starting at endpoint. fetchPage is
+// called once per page and returns the page's `links.next` value; walking
+// stops when it is empty or MaxPages is exceeded.
+func (c *HackerOneClient) paginate(ctx context.Context, endpoint string, fetchPage func(endpoint string) (string, error)) error {
+	sep := "?"
+	if strings.Contains(endpoint, "?") {
+		sep = "&"
+	}
+	endpoint = fmt.Sprintf("%s%spage[number]=1&page[size]=%d", endpoint, sep, c.pageSize)
+
+	for page := 1; endpoint != ""; page++ {
+		if page > c.maxPages {
+			return fmt.Errorf("exceeded maximum of %d pages at endpoint %s", c.maxPages, endpoint)
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		next, err := fetchPage(endpoint)
+		if err != nil {
+			return err
+		}
+
+		endpoint, err = c.relativeEndpoint(next)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// relativeEndpoint strips scheme and host from a `links.next` URL so that
+// follow-up pages are always requested from the configured base URL and
+// credentials are never sent to a host returned by the API.
+func (c *HackerOneClient) relativeEndpoint(link string) (string, error) {
+	if link == "" {
+		return "", nil
+	}
+
+	u, err := url.Parse(link)
+	if err != nil {
+		return "", fmt.Errorf("parsing next link %q: %w", link, err)
+	}
+
+	base, err := url.Parse(c.baseURL)
+	if err != nil {
+		return "", fmt.Errorf("parsing base URL: %w", err)
+	}
+
+	return strings.TrimPrefix(u.RequestURI(), strings.TrimSuffix(base.Path, "/")), nil
+}
+
+// GetAssets retrieves all Assets for an Organization ID
+// https://api.hackerone.com/customer-resources/?shell#assets-get-all-assets
+func (c *HackerOneClient) GetAssets(ctx context.Context, orgID string) (*types.Assets, error) {
+	var assets types.Assets
+	endpoint := fmt.Sprintf("/v1/organizations/%s/assets", url.PathEscape(orgID))
+
+	err := c.paginate(ctx, endpoint, func(endpoint string) (string, error) {
+		var page types.Assets
+		if err := c.makeRequest(ctx, endpoint, &page); err != nil {
+			return "", err
+		}
+		assets.Data = append(assets.Data, page.Data...)
+		return page.Links.Next, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting assets for organization %s: %w", orgID, err)
+	}
+
+	c.logger.Debug("Retrieved assets",
+		slog.String("organization_id", orgID),
+		slog.Int("count", len(assets.Data)))
+
+	return &assets, nil
+}
+
+// GetAllReports retrieves all Reports for an Organization ID
+// https://api.hackerone.com/customer-resources/?shell#reports-get-all-reports
+func (c *HackerOneClient) GetAllReports(ctx context.Context, programHandle string) (*types.Reports, error) {
+	var reports types.Reports
+	endpoint := fmt.Sprintf("/v1/reports?filter[program][]=%s", url.QueryEscape(programHandle))
+
+	err := c.paginate(ctx, endpoint, func(endpoint string) (string, error) {
+		var page types.Reports
+		if err := c.makeRequest(ctx, endpoint, &page); err != nil {
+			return "", err
+		}
+		reports.Data = append(reports.Data, page.Data...)
+		return page.Links.Next, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting reports for program %s: %w", programHandle, err)
+	}
+
+	c.logger.Debug("Retrieved reports",
+		slog.String("program", programHandle),
+		slog.Int("count", len(reports.Data)))
+
+	return &reports, nil
+}
+
+// GetPrograms retrieves all Programs
+// https://api.hackerone.com/customer-resources/?shell#programs-get-your-programs
+func (c *HackerOneClient) GetPrograms(ctx context.Context) (*types.Programs, error) {
+	var programs types.Programs
+	endpoint := "/v1/me/programs"
+
+	err := c.paginate(ctx, endpoint, func(endpoint string) (string, error) {
+		var page types.Programs
+		if err := c.makeRequest(ctx, endpoint, &page); err != nil {
+			return "", err
+		}
+		programs.Data = append(programs.Data, page.Data...)
+		return page.Links.Next, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting programs: %w", err)
+	}
+
+	c.logger.Debug("Retrieved programs",
+		slog.Int("count", len(programs.Data)))
+
+	return &programs, nil
+}
+
+// GetInvitedHackers retrieves all Hackers invited to the HackerOne program
+// https://api.hackerone.com/customer-resources/#programs-get-hacker-invitations
+func (c *HackerOneClient) GetInvitedHackers(ctx context.Context, programID string) (*types.InvitedHackers, error) {
+	var hackers types.InvitedHackers
+	endpoint := fmt.Sprintf("/v1/programs/%s/hacker_invitations", url.PathEscape(programID))
+
+	err := c.paginate(ctx, endpoint, func(endpoint string) (string, error) {
+		var page types.InvitedHackers
+		if err := c.makeRequest(ctx, endpoint, &page); err != nil {
+			return "", err
+		}
+		hackers.Data = append(hackers.Data, page.Data...)
+		return page.Links.Next, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting hackers for program %s: %w", programID, err)
+	}
+
+	c.logger.Debug("Retrieved hackers",
+		slog.String("programID", programID),
+		slog.Int("count", len(hackers.Data)))
+
+	return &hackers, nil
+}
+
+// GetWeaknesses retrieves all Weaknesses for the program
+// https://api.hackerone.com/customer-resources/#programs-get-weaknesses
+func (c *HackerOneClient) GetWeaknesses(ctx context.Context, programID string) (*types.Weaknesses, error) {
+	var weaknesses types.Weaknesses
+	endpoint := fmt.Sprintf("/v1/programs/%s/weaknesses", url.PathEscape(programID))
+
+	err := c.paginate(ctx, endpoint, func(endpoint string) (string, error) {
+		var page types.Weaknesses
+		if err := c.makeRequest(ctx, endpoint, &page); err != nil {
+			return "", err
+		}
+		weaknesses.Data = append(weaknesses.Data, page.Data...)
+		return page.Links.Next, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting weaknesses for program %s: %w", programID, err)
+	}
+
+	c.logger.Debug("Retrieved weaknesses",
+		slog.String("programID", programID),
+		slog.Int("count", len(weaknesses.Data)))
+
+	return &weaknesses, nil
+}
+
+// GetStructuredScopes retrieves all structured scopes for the program
+// https://api.hackerone.com/customer-resources/#programs-get-structured-scopes
+func (c *HackerOneClient) GetStructuredScopes(ctx context.Context, programID string) (*types.StructuredScopes, error) {
+	var scopes types.StructuredScopes
+	endpoint := fmt.Sprintf("/v1/programs/%s/structured_scopes", url.PathEscape(programID))
+
+	err := c.paginate(ctx, endpoint, func(endpoint string) (string, error) {
+		var page types.StructuredScopes
+		if err := c.makeRequest(ctx, endpoint, &page); err != nil {
+			return "", err
+		}
+		scopes.Data = append(scopes.Data, page.Data...)
+		return page.Links.Next, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting structured scopes for program %s: %w", programID, err)
+	}
+
+	c.logger.Debug("Retrieved structured scopes",
+		slog.String("programID", programID),
+		slog.Int("count", len(scopes.Data)))
+
+	return &scopes, nil
+}
+
+// GetReporters retrieves all Reporters for a program
+// https://api.hackerone.com/customer-resources/#programs-get-reporters
+func (c *HackerOneClient) GetReporters(ctx context.Context, programID string) (*types.Reporters, error) {
+	var reporters types.Reporters
+	endpoint := fmt.Sprintf("/v1/programs/%s/reporters", url.PathEscape(programID))
+
+	err := c.paginate(ctx, endpoint, func(endpoint string) (string, error) {
+		var page types.Reporters
+		if err := c.makeRequest(ctx, endpoint, &page); err != nil {
+			return "", err
+		}
+		reporters.Data = append(reporters.Data, page.Data...)
+		return page.Links.Next, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting reporters for program %s: %w", programID, err)
+	}
+
+	c.logger.Debug("Retrieved reporters",
+		slog.String("programID", programID),
+		slog.Int("count", len(reporters.Data)))
+
+	return &reporters, nil
+}
+
+// GetProgramBalance retrieves the current balance of the program
+// https://api.hackerone.com/customer-resources/#billing-get-balance
+func (c *HackerOneClient) GetProgramBalance(ctx context.Context, programID string) (*types.ProgramBalance, error) {
+	var balance types.ProgramBalance
+	endpoint := fmt.Sprintf("/v1/programs/%s/billing/balance", url.PathEscape(programID))
+
+	if err := c.makeRequest(ctx, endpoint, &balance); err != nil {
+		return nil, fmt.Errorf("getting balance for program %s: %w", programID, err)
+	}
+
+	c.logger.Debug("Retrieved program balance",
+		slog.String("programID", programID))
+
+	return &balance, nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dirsigler/hackerone-exporter/internal/h1fake"
+)
+
+// newTestClient returns a client of a fake API serving five assets. Retries
+// back off for a millisecond only to keep the tests fast. Options.Metrics is
+// left nil, which the client must handle.
+func newTestClient(t *testing.T, opts Options) (*HackerOneClient, *h1fake.Server) {
+	t.Helper()
+
+	fake := h1fake.New(h1fake.Minimal(5))
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	opts.BaseURL = server.URL
+	c := New(opts, slog.New(slog.DiscardHandler))
+	c.client.RetryWaitMin = time.Millisecond
+	c.client.RetryWaitMax = time.Millisecond
+
+	return c, fake
+}
+
+func TestPagination(t *testing.T) {
+	c, fake := newTestClient(t, Options{PageSize: 2})
+
+	assets, err := c.GetAssets(context.Background(), "42")
+	if err != nil {
+		t.Fatalf("GetAssets() error = %v", err)
+	}
+
+	var domains []string
+	for _, asset := range assets.Data {
+		domains = append(domains, asset.Attributes.DomainName)
+	}
+	want := "1.example.com,2.example.com,3.example.com,4.example.com,5.example.com"
+	if got := strings.Join(domains, ","); got != want {
+		t.Errorf("GetAssets() domains = %s, want %s", got, want)
+	}
+	if got := fake.Requests("/v1/organizations/42/assets"); got != 3 {
+		t.Errorf("requests = %d, want 3 pages", got)
+	}
+}
+
+func TestPaginationMaxPages(t *testing.T) {
+	c, fake := newTestClient(t, Options{PageSize: 2, MaxPages: 2})
+
+	_, err := c.GetAssets(context.Background(), "42")
+	if err == nil || !strings.Contains(err.Error(), "exceeded maximum of 2 pages") {
+		t.Fatalf("GetAssets() error = %v, want the maximum of pages to be exceeded", err)
+	}
+	if got := fake.Requests("/v1/organizations/42/assets"); got != 2 {
+		t.Errorf("requests = %d, want 2", got)
+	}
+}
+
+func TestInjectedFailures(t *testing.T) {
+	tests := []struct {
+		status int
+		// requests is 1 for statuses that are not retried and the first
+		// attempt plus retryablehttp's default of four retries otherwise
+		requests int
+		is       func(err error) bool
+	}{
+		{http.StatusUnauthorized, 1, func(err error) bool { var target *AuthError; return errors.As(err, &target) }},
+		{http.StatusForbidden, 1, func(err error) bool { var target *ForbiddenError; return errors.As(err, &target) }},
+		{http.StatusTooManyRequests, 5, func(err error) bool { var target *RateLimitError; return errors.As(err, &target) }},
+		{http.StatusServiceUnavailable, 5, func(err error) bool { var target *ServerError; return errors.As(err, &target) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			c, fake := newTestClient(t, Options{})
+			fake.Fail("/v1/programs/1/weaknesses", tt.status)
+
+			_, err := c.GetWeaknesses(context.Background(), "1")
+			if !tt.is(err) {
+				t.Fatalf("GetWeaknesses() error = %v, want the error of status %d", err, tt.status)
+			}
+			if got := fake.Requests("/v1/programs/1/weaknesses"); got != tt.requests {
+				t.Errorf("requests = %d, want %d", got, tt.requests)
+			}
+
+			// Other endpoints keep working
+			if _, err := c.GetPrograms(context.Background()); err != nil {
+				t.Errorf("GetPrograms() error = %v", err)
+			}
+
+			fake.Fail("/v1/programs/1/weaknesses", 0)
+			weaknesses, err := c.GetWeaknesses(context.Background(), "1")
+			if err != nil {
+				t.Fatalf("GetWeaknesses() after clearing the failure error = %v", err)
+			}
+			if len(weaknesses.Data) != 1 {
+				t.Errorf("GetWeaknesses() returned %d weaknesses, want 1", len(weaknesses.Data))
+			}
+		})
+	}
+}
+
+func TestCredentials(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		wantErr  bool
+	}{
+		{name: "valid", password: "secret"},
+		{name: "invalid", password: "wrong", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, fake := newTestClient(t, Options{Username: "hacker", Password: tt.password})
+			fake.SetCredentials("hacker", "secret")
+
+			_, err := c.GetPrograms(context.Background())
+			var authErr *AuthError
+			if got := errors.As(err, &authErr); got != tt.wantErr {
+				t.Fatalf("GetPrograms() error = %v, want an AuthError: %t", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dirsigler/hackerone-exporter/pkg/metrics"
+	"golang.org/x/time/rate"
+)
+
+// idSegment matches numeric path segments such as program or organization IDs
+var idSegment = regexp.MustCompile(`/\d+(/|$)`)
+
+// Limiter paces the requests of every client it is shared with and holds
+// them all back while the HackerOne API asks for a pause with Retry-After.
+// Clients using the same credentials share a quota and should share a Limiter.
+type Limiter struct {
+	limiter *rate.Limiter
+
+	mu           sync.Mutex
+	blockedUntil time.Time
+}
+
+// NewLimiter creates a Limiter allowing limit requests per second with the
+// given burst. A limit of 0 disables rate limiting.
+func NewLimiter(limit float64, burst int) *Limiter {
+	l := rate.Inf
+	if limit > 0 {
+		l = rate.Limit(limit)
+	}
+	return &Limiter{limiter: rate.NewLimiter(l, max(burst, 1))}
+}
+
+// wait blocks until a request may be sent
+func (l *Limiter) wait(ctx context.Context) error {
+	if wait := l.pause(); wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if err := l.limiter.Wait(ctx); err != nil {
+		// Wait gives up early if ctx ends before the next request is allowed
+		if ctx.Err() == nil {
+			return fmt.Errorf("waiting for the rate limit: %w", context.DeadlineExceeded)
+		}
+		return err
+	}
+	return nil
+}
+
+// pause returns how long requests must wait before the API accepts them again
+func (l *Limiter) pause() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return time.Until(l.blockedUntil)
+}
+
+// block holds back all requests sharing the limiter for the given duration so
+// that concurrent workers do not keep hitting an exhausted quota.
+func (l *Limiter) block(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until := time.Now().Add(d); until.After(l.blockedUntil) {
+		l.blockedUntil = until
+	}
+}
+
+// transport rate limits and instruments every HTTP attempt made against the
+// HackerOne API, including retries issued by retryablehttp.
+type transport struct {
+	next    http.RoundTripper
+	limiter *Limiter
+	metrics *metrics.Metrics
+	logger  *slog.Logger
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	endpoint := endpointLabel(req.URL.Path)
+	start := time.Now()
+
+	resp, err := t.next.RoundTrip(req)
+
+	t.metrics.APIRequestDuration.WithLabelValues(endpoint).Observe(time.Since(start).Seconds())
+	if err != nil {
+		t.metrics.APIRequests.WithLabelValues(endpoint, "error").Inc()
+		return nil, err
+	}
+	t.metrics.APIRequests.WithLabelValues(endpoint, strconv.Itoa(resp.StatusCode)).Inc()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		t.metrics.APIThrottled.Inc()
+
+		retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"))
+		if ok {
+			t.limiter.block(retryAfter)
+		}
+
+		t.logger.Warn("HackerOne API rate limit reached",
+			slog.String("endpoint", endpoint),
+			slog.Duration("retry_after", retryAfter))
+	}
+
+	return resp, nil
+}
+
+// backoff honours the Retry-After header of throttled responses and falls
+// back to exponential backoff otherwise.
+func backoff(minWait, maxWait time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return retryAfter
+		}
+	}
+
+	wait := minWait << attemptNum
+	if wait <= 0 || wait > maxWait {
+		wait = maxWait
+	}
+	return wait
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as
+// an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+// endpointLabel turns a request path into a low-cardinality metric label by
+// replacing numeric IDs, e.g. /v1/programs/123/reporters becomes
+// /v1/programs/{id}/reporters.
+func endpointLabel(path string) string {
+	// Replace twice since adjacent matches share their separating slash
+	path = idSegment.ReplaceAllString(path, "/{id}$1")
+	return idSegment.ReplaceAllString(path, "/{id}$1")
+}
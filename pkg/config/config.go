@@ -0,0 +1,431 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dirsigler/hackerone-exporter/pkg/sla"
+	"github.com/urfave/cli/v3"
+)
+
+// collectorFlagPrefix is the prefix of the per-collector toggle flags
+const collectorFlagPrefix = "collector."
+
+// Defaults of the flags that SetDefaults also applies to configurations
+// built without flags
+const (
+	defaultAPIURL            = "https://api.hackerone.com"
+	defaultScrapeInterval    = 60 * time.Second
+	defaultScrapeConcurrency = 4
+)
+
+// Config holds the application configuration
+type Config struct {
+	Demo              bool
+	ConfigFile        string
+	WebConfigFile     string
+	Organizations     []Organization
+	Modules           map[string]Module
+	Port              int64
+	LogLevel          string
+	APIURL            string
+	RecordDir         string
+	ReplayDir         string
+	PageSize          int64
+	MaxPages          int64
+	ScrapeInterval    time.Duration
+	RateLimit         float64
+	RateBurst         int64
+	ScrapeConcurrency int64
+	ReadyMaxScrapeAge time.Duration
+	Collectors        map[string]bool
+	SLATargets        []sla.Target
+	ReportLabels      []string
+	ReportersPerUser  bool
+}
+
+// Credentials authenticate against the HackerOne API. The user and password
+// are given either inline or as files, which are re-read when they change so
+// that rotated secrets are picked up without a restart.
+type Credentials struct {
+	APIUser         string `yaml:"api_user"`
+	APIUserFile     string `yaml:"api_user_file"`
+	APIPassword     string `yaml:"api_password"`
+	APIPasswordFile string `yaml:"api_password_file"`
+}
+
+// withDefaults fills the user and password that are not set with the ones
+// of defaults
+func (c Credentials) withDefaults(defaults Credentials) Credentials {
+	if c.APIUser == "" && c.APIUserFile == "" {
+		c.APIUser, c.APIUserFile = defaults.APIUser, defaults.APIUserFile
+	}
+	if c.APIPassword == "" && c.APIPasswordFile == "" {
+		c.APIPassword, c.APIPasswordFile = defaults.APIPassword, defaults.APIPasswordFile
+	}
+	return c
+}
+
+// validate checks that exactly one source is set for the user and the
+// password and that credential files are readable
+func (c Credentials) validate() error {
+	switch {
+	case c.APIUser == "" && c.APIUserFile == "":
+		return fmt.Errorf("HackerOne API user is required")
+	case c.APIUser != "" && c.APIUserFile != "":
+		return fmt.Errorf("HackerOne API user and API user file are mutually exclusive")
+	case c.APIPassword == "" && c.APIPasswordFile == "":
+		return fmt.Errorf("HackerOne API password is required")
+	case c.APIPassword != "" && c.APIPasswordFile != "":
+		return fmt.Errorf("HackerOne API password and API password file are mutually exclusive")
+	}
+
+	for _, path := range []string{c.APIUserFile, c.APIPasswordFile} {
+		if path == "" {
+			continue
+		}
+		if _, err := os.ReadFile(path); err != nil {
+			return fmt.Errorf("reading credentials file: %w", err)
+		}
+	}
+	return nil
+}
+
+// Organization is a HackerOne organization and the API credentials used to
+// scrape it
+type Organization struct {
+	ID          string `yaml:"id"`
+	Credentials `yaml:",inline"`
+}
+
+// Module holds the API credentials and collectors used by /probe requests
+// that select it. An empty Collectors list runs every enabled collector.
+type Module struct {
+	Credentials `yaml:",inline"`
+	Collectors  []string `yaml:"collectors"`
+}
+
+// New creates a new Config struct from the cli.Command
+func New(cmd *cli.Command) (*Config, error) {
+	var targets []sla.Target
+	for _, value := range cmd.StringSlice("sla.target") {
+		target, err := sla.ParseTarget(value)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, target)
+	}
+
+	cfg := &Config{
+		Demo:              cmd.Bool("demo"),
+		ConfigFile:        cmd.String("config.file"),
+		WebConfigFile:     cmd.String("web.config.file"),
+		Port:              cmd.Int("port"),
+		LogLevel:          cmd.String("log-level"),
+		APIURL:            cmd.String("api-url"),
+		RecordDir:         cmd.String("api.record-dir"),
+		ReplayDir:         cmd.String("api.replay-dir"),
+		PageSize:          cmd.Int("api-page-size"),
+		MaxPages:          cmd.Int("api-max-pages"),
+		ScrapeInterval:    time.Duration(cmd.Int("scrape-interval")) * time.Second,
+		RateLimit:         cmd.Float("api-rate-limit"),
+		RateBurst:         cmd.Int("api-rate-burst"),
+		ScrapeConcurrency: cmd.Int("scrape-concurrency"),
+		ReadyMaxScrapeAge: time.Duration(cmd.Int("ready-max-scrape-age")) * time.Second,
+		Collectors:        enabledCollectors(cmd),
+		SLATargets:        targets,
+		ReportLabels:      cmd.StringSlice("collector.reports.labels"),
+		ReportersPerUser:  cmd.Bool("collector.reporters.per-user"),
+	}
+
+	// Flags describe at most one organization, more can be added in the
+	// configuration file. The credentials given by flags are the defaults
+	// for organizations and modules of the file.
+	flagCredentials := Credentials{
+		APIUser:         cmd.String("api-user"),
+		APIUserFile:     cmd.String("api-user-file"),
+		APIPassword:     cmd.String("api-password"),
+		APIPasswordFile: cmd.String("api-password-file"),
+	}
+	orgID := cmd.String("org-id")
+
+	// Neither the fake API of the demo mode nor replayed responses check
+	// credentials
+	if cfg.Demo || cfg.ReplayDir != "" {
+		flagCredentials = flagCredentials.withDefaults(Credentials{APIUser: "unused", APIPassword: "unused"})
+	}
+	if cfg.Demo && orgID == "" {
+		orgID = "demo"
+	}
+
+	if orgID != "" {
+		cfg.Organizations = []Organization{{ID: orgID, Credentials: flagCredentials}}
+	}
+
+	if cfg.ConfigFile != "" {
+		f, err := LoadFile(cfg.ConfigFile)
+		if err != nil {
+			return nil, err
+		}
+		f.apply(cfg, flagCredentials)
+	}
+
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// SetDefaults fills the options left unset, which happens when programs
+// embedding the exporter build the configuration themselves
+func (c *Config) SetDefaults() {
+	if c.APIURL == "" {
+		c.APIURL = defaultAPIURL
+	}
+	if c.ScrapeInterval == 0 {
+		c.ScrapeInterval = defaultScrapeInterval
+	}
+	if c.ScrapeConcurrency == 0 {
+		c.ScrapeConcurrency = defaultScrapeConcurrency
+	}
+
+	// Tolerate a couple of failed scrapes before reporting unreadiness
+	if c.ReadyMaxScrapeAge == 0 {
+		c.ReadyMaxScrapeAge = 3 * c.ScrapeInterval
+	}
+}
+
+// Validate checks that the options required to scrape the HackerOne API are
+// set, no matter whether they came from flags or the configuration file.
+func (c *Config) Validate() error {
+	if len(c.Organizations) == 0 && len(c.Modules) == 0 {
+		return fmt.Errorf("HackerOne organization ID is required unless probe modules are configured")
+	}
+
+	seen := make(map[string]bool)
+	for _, org := range c.Organizations {
+		switch {
+		case org.ID == "":
+			return fmt.Errorf("HackerOne organization ID is required")
+		case seen[org.ID]:
+			return fmt.Errorf("duplicate HackerOne organization %q", org.ID)
+		}
+		if err := org.Credentials.validate(); err != nil {
+			return fmt.Errorf("organization %q: %w", org.ID, err)
+		}
+		seen[org.ID] = true
+	}
+
+	for name, module := range c.Modules {
+		if err := module.Credentials.validate(); err != nil {
+			return fmt.Errorf("module %q: %w", name, err)
+		}
+	}
+
+	switch {
+	case c.RecordDir != "" && c.ReplayDir != "":
+		return fmt.Errorf("recording and replaying API responses are mutually exclusive")
+	case c.Demo && c.ReplayDir != "":
+		return fmt.Errorf("demo mode and replaying API responses are mutually exclusive")
+	case c.ScrapeInterval <= 0:
+		return fmt.Errorf("scrape interval must be positive, got %s", c.ScrapeInterval)
+	case c.ScrapeConcurrency <= 0:
+		return fmt.Errorf("scrape concurrency must be positive, got %d", c.ScrapeConcurrency)
+	case c.ReadyMaxScrapeAge < 0:
+		return fmt.Errorf("maximum scrape age for readiness must not be negative, got %s", c.ReadyMaxScrapeAge)
+	}
+	return nil
+}
+
+// enabledCollectors resolves every --collector.<name> flag, honouring its
+// --no-collector.<name> negation, into a map of collector name to state.
+func enabledCollectors(cmd *cli.Command) map[string]bool {
+	collectors := make(map[string]bool)
+	for _, flag := range cmd.Flags {
+		name := flag.Names()[0]
+		collector, ok := strings.CutPrefix(name, collectorFlagPrefix)
+		if !ok || strings.Contains(collector, ".") {
+			// Skip collector options such as --collector.reports.labels
+			continue
+		}
+
+		collectors[collector] = cmd.Bool(name) && !cmd.Bool("no-"+name)
+	}
+	return collectors
+}
+
+// SetupLogger configures the structured logger based on config
+func (c *Config) SetupLogger() *slog.Logger {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		level = slog.LevelInfo
+	}
+
+	opts := &slog.HandlerOptions{
+		Level: level,
+	}
+
+	handler := slog.NewTextHandler(os.Stdout, opts)
+	return slog.New(handler)
+}
+
+// CLIFlags returns the CLI flags for the application
+func CLIFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:    "demo",
+			Usage:   "Scrape an in-process fake of the HackerOne API serving generated data instead of api-url",
+			Sources: cli.EnvVars("HACKERONE_DEMO"),
+		},
+		&cli.StringFlag{
+			Name:    "config.file",
+			Usage:   "Path to a YAML configuration file, reloaded on SIGHUP or POST /-/reload",
+			Sources: cli.EnvVars("HACKERONE_CONFIG_FILE"),
+		},
+		&cli.StringFlag{
+			Name:    "web.config.file",
+			Usage:   "Path to a web configuration file enabling TLS or basic authentication (exporter-toolkit format)",
+			Sources: cli.EnvVars("WEB_CONFIG_FILE"),
+		},
+		&cli.StringFlag{
+			Name:    "api-user",
+			Usage:   "HackerOne API Username (required unless set in the configuration file)",
+			Sources: cli.EnvVars("HACKERONE_API_USER"),
+		},
+		&cli.StringFlag{
+			Name:    "api-user-file",
+			Usage:   "File containing the HackerOne API Username, re-read when it changes",
+			Sources: cli.EnvVars("HACKERONE_API_USER_FILE"),
+		},
+		&cli.StringFlag{
+			Name:    "api-password",
+			Usage:   "HackerOne API Password (required unless set in the configuration file)",
+			Sources: cli.EnvVars("HACKERONE_API_PASSWORD"),
+		},
+		&cli.StringFlag{
+			Name:    "api-password-file",
+			Usage:   "File containing the HackerOne API Password, re-read when it changes",
+			Sources: cli.EnvVars("HACKERONE_API_PASSWORD_FILE"),
+		},
+		&cli.IntFlag{
+			Name:    "port",
+			Usage:   "Port to listen on",
+			Sources: cli.EnvVars("PORT"),
+			Value:   8080,
+		},
+		&cli.IntFlag{
+			Name:    "scrape-interval",
+			Usage:   "Scrape interval in seconds",
+			Sources: cli.EnvVars("SCRAPE_INTERVAL"),
+			Value:   int64(defaultScrapeInterval / time.Second),
+			Validator: func(v int64) error {
+				if v <= 0 {
+					return fmt.Errorf("scrape interval must be positive, got %d", v)
+				}
+				return nil
+			},
+		},
+		&cli.IntFlag{
+			Name:    "scrape-concurrency",
+			Usage:   "Maximum number of concurrent HackerOne API requests per scrape",
+			Sources: cli.EnvVars("SCRAPE_CONCURRENCY"),
+			Value:   defaultScrapeConcurrency,
+			Validator: func(v int64) error {
+				if v <= 0 {
+					return fmt.Errorf("scrape concurrency must be positive, got %d", v)
+				}
+				return nil
+			},
+		},
+		&cli.IntFlag{
+			Name:    "ready-max-scrape-age",
+			Usage:   "Seconds after the last successful scrape until /readyz fails (0 means three scrape intervals)",
+			Sources: cli.EnvVars("READY_MAX_SCRAPE_AGE"),
+		},
+		&cli.StringFlag{
+			Name:    "log-level",
+			Usage:   "Log level (debug, info, warn, error)",
+			Sources: cli.EnvVars("LOG_LEVEL"),
+			Value:   "info",
+		},
+		&cli.StringFlag{
+			Name:    "org-id",
+			Usage:   "HackerOne Organization ID (required unless set in the configuration file)",
+			Sources: cli.EnvVars("HACKERONE_ORG_ID"),
+		},
+		&cli.StringFlag{
+			Name:    "api-url",
+			Usage:   "HackerOne API URL",
+			Sources: cli.EnvVars("HACKERONE_API_URL"),
+			Value:   defaultAPIURL,
+			Hidden:  true,
+		},
+		&cli.StringSliceFlag{
+			Name:    "collector.reports.labels",
+			Usage:   "Labels of hackerone_reports_total besides organization_id (program, state, severity_rating, weakness_external_id, asset_type)",
+			Sources: cli.EnvVars("HACKERONE_REPORT_LABELS"),
+			Value:   []string{"state"},
+		},
+		&cli.BoolFlag{
+			Name:    "collector.reporters.per-user",
+			Usage:   "Expose reputation, signal and impact of every reporter, one series per username and program",
+			Sources: cli.EnvVars("HACKERONE_REPORTERS_PER_USER"),
+		},
+		&cli.StringSliceFlag{
+			Name:  "sla.target",
+			Usage: "SLA target as [<program>:]<severity>:<stage>:<within>, e.g. critical:triage:24h (repeatable, stages: first_response, triage, bounty, resolution)",
+		},
+		&cli.IntFlag{
+			Name:    "api-page-size",
+			Usage:   "Number of items requested per page from the HackerOne API (max 100)",
+			Sources: cli.EnvVars("HACKERONE_API_PAGE_SIZE"),
+			Value:   100,
+		},
+		&cli.IntFlag{
+			Name:    "api-max-pages",
+			Usage:   "Maximum number of pages followed for a single HackerOne API collection",
+			Sources: cli.EnvVars("HACKERONE_API_MAX_PAGES"),
+			Value:   500,
+		},
+		&cli.FloatFlag{
+			Name:    "api-rate-limit",
+			Usage:   "Maximum HackerOne API requests per second (0 disables the limit)",
+			Sources: cli.EnvVars("HACKERONE_API_RATE_LIMIT"),
+			Value:   5,
+		},
+		&cli.IntFlag{
+			Name:    "api-rate-burst",
+			Usage:   "Number of HackerOne API requests allowed to burst above the rate limit",
+			Sources: cli.EnvVars("HACKERONE_API_RATE_BURST"),
+			Value:   10,
+		},
+		&cli.StringFlag{
+			Name:    "api.record-dir",
+			Usage:   "Directory to write every HackerOne API response to as a sanitized fixture, one subdirectory per organization",
+			Sources: cli.EnvVars("HACKERONE_API_RECORD_DIR"),
+		},
+		&cli.StringFlag{
+			Name:    "api.replay-dir",
+			Usage:   "Directory of fixtures written by api.record-dir to serve scrapes from instead of the HackerOne API",
+			Sources: cli.EnvVars("HACKERONE_API_REPLAY_DIR"),
+		},
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/dirsigler/hackerone-exporter/pkg/sla"
+	"github.com/prometheus/common/model"
+	"gopkg.in/yaml.v3"
+)
+
+// File is the layout of the YAML configuration file. Every option is
+// optional; options left out keep the value of the corresponding flag.
+type File struct {
+	LogLevel          string                   `yaml:"log_level"`
+	Port              int64                    `yaml:"port"`
+	ScrapeInterval    model.Duration           `yaml:"scrape_interval"`
+	ScrapeConcurrency int64                    `yaml:"scrape_concurrency"`
+	ReadyMaxScrapeAge model.Duration           `yaml:"ready_max_scrape_age"`
+	API               APIFile                  `yaml:"api"`
+	Organizations     []Organization           `yaml:"organizations"`
+	Modules           map[string]Module        `yaml:"modules"`
+	Collectors        map[string]CollectorFile `yaml:"collectors"`
+	SLA               SLAFile                  `yaml:"sla"`
+}
+
+// APIFile configures how the HackerOne API is queried
+type APIFile struct {
+	URL       string   `yaml:"url"`
+	PageSize  int64    `yaml:"page_size"`
+	MaxPages  int64    `yaml:"max_pages"`
+	RateLimit *float64 `yaml:"rate_limit"`
+	RateBurst int64    `yaml:"rate_burst"`
+}
+
+// CollectorFile enables a collector and holds its options. Options only apply
+// to the collector named in the comment next to them.
+type CollectorFile struct {
+	Enabled *bool    `yaml:"enabled"`
+	Labels  []string `yaml:"labels"`   // reports
+	PerUser *bool    `yaml:"per_user"` // reporters
+}
+
+// SLAFile holds the SLA policy
+type SLAFile struct {
+	Targets []SLATargetFile `yaml:"targets"`
+}
+
+// SLATargetFile is an SLA target, see sla.Target
+type SLATargetFile struct {
+	Program  string         `yaml:"program"`
+	Severity string         `yaml:"severity"`
+	Stage    sla.Stage      `yaml:"stage"`
+	Within   model.Duration `yaml:"within"`
+}
+
+// LoadFile reads and strictly parses a YAML configuration file. Unknown keys
+// are rejected so that typos do not go unnoticed.
+func LoadFile(path string) (*File, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+
+	var f File
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&f); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+
+	if err := f.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return &f, nil
+}
+
+// validate checks the options the file can express but flags cannot
+func (f *File) validate() error {
+	for i, org := range f.Organizations {
+		if org.ID == "" {
+			return fmt.Errorf("organizations[%d]: id is required", i)
+		}
+	}
+
+	for name, c := range f.Collectors {
+		if c.Labels != nil && name != "reports" {
+			return fmt.Errorf("collectors.%s: labels are only supported by the reports collector", name)
+		}
+		if c.PerUser != nil && name != "reporters" {
+			return fmt.Errorf("collectors.%s: per_user is only supported by the reporters collector", name)
+		}
+	}
+
+	for i, t := range f.SLA.Targets {
+		if err := t.target().Validate(); err != nil {
+			return fmt.Errorf("sla.targets[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (t SLATargetFile) target() sla.Target {
+	return sla.Target{
+		Program:  t.Program,
+		Severity: t.Severity,
+		Stage:    t.Stage,
+		Within:   time.Duration(t.Within),
+	}
+}
+
+// apply overrides the options of c that are set in the file. Organizations
+// and modules without credentials of their own use the ones of defaults.
+func (f *File) apply(c *Config, defaults Credentials) {
+	if f.LogLevel != "" {
+		c.LogLevel = f.LogLevel
+	}
+	if f.Port != 0 {
+		c.Port = f.Port
+	}
+	if f.ScrapeInterval != 0 {
+		c.ScrapeInterval = time.Duration(f.ScrapeInterval)
+	}
+	if f.ScrapeConcurrency != 0 {
+		c.ScrapeConcurrency = f.ScrapeConcurrency
+	}
+	if f.ReadyMaxScrapeAge != 0 {
+		c.ReadyMaxScrapeAge = time.Duration(f.ReadyMaxScrapeAge)
+	}
+
+	if f.API.URL != "" {
+		c.APIURL = f.API.URL
+	}
+	if f.API.PageSize != 0 {
+		c.PageSize = f.API.PageSize
+	}
+	if f.API.MaxPages != 0 {
+		c.MaxPages = f.API.MaxPages
+	}
+	if f.API.RateLimit != nil {
+		c.RateLimit = *f.API.RateLimit
+	}
+	if f.API.RateBurst != 0 {
+		c.RateBurst = f.API.RateBurst
+	}
+
+	if len(f.Organizations) > 0 {
+		c.Organizations = nil
+		for _, org := range f.Organizations {
+			org.Credentials = org.Credentials.withDefaults(defaults)
+			c.Organizations = append(c.Organizations, org)
+		}
+	}
+
+	if len(f.Modules) > 0 {
+		c.Modules = make(map[string]Module, len(f.Modules))
+		for name, module := range f.Modules {
+			module.Credentials = module.Credentials.withDefaults(defaults)
+			c.Modules[name] = module
+		}
+	}
+
+	for name, collector := range f.Collectors {
+		if collector.Enabled != nil {
+			c.Collectors[name] = *collector.Enabled
+		}
+		if collector.Labels != nil {
+			c.ReportLabels = collector.Labels
+		}
+		if collector.PerUser != nil {
+			c.ReportersPerUser = *collector.PerUser
+		}
+	}
+
+	if len(f.SLA.Targets) > 0 {
+		c.SLATargets = nil
+		for _, t := range f.SLA.Targets {
+			c.SLATargets = append(c.SLATargets, t.target())
+		}
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dirsigler/hackerone-exporter/pkg/config"
+	"github.com/urfave/cli/v3"
+)
+
+// writeFile writes content to a configuration file in a temporary directory
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// newConfig builds the configuration from the command line args and, if
+// file is not empty, a configuration file with that content
+func newConfig(t *testing.T, file string, args ...string) (*config.Config, error) {
+	t.Helper()
+
+	if file != "" {
+		args = append(args, "--config.file", writeFile(t, file))
+	}
+
+	var (
+		cfg *config.Config
+		err error
+	)
+	cmd := &cli.Command{
+		Name: "hackerone-exporter",
+		Flags: append(config.CLIFlags(),
+			&cli.BoolFlag{Name: "collector.reports", Value: true},
+			&cli.BoolFlag{Name: "no-collector.reports"},
+		),
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			cfg, err = config.New(cmd)
+			return nil
+		},
+	}
+	if runErr := cmd.Run(context.Background(), append([]string{"hackerone-exporter"}, args...)); runErr != nil {
+		t.Fatalf("running command: %v", runErr)
+	}
+	return cfg, err
+}
+
+func TestLoadFileInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"unknown key", "scrape_interval: 1m\nscrape_intervall: 2m\n", "field scrape_intervall not found"},
+		{"unknown nested key", "api:\n  rate: 5\n", "field rate not found"},
+		{"organization without id", "organizations:\n  - api_user: hacker\n", "organizations[0]: id is required"},
+		{"labels of another collector", "collectors:\n  assets:\n    labels: [state]\n", "labels are only supported by the reports collector"},
+		{"per_user of another collector", "collectors:\n  reports:\n    per_user: true\n", "per_user is only supported by the reporters collector"},
+		{"sla target without severity", "sla:\n  targets:\n    - stage: triage\n      within: 1d\n", "sla.targets[0]: severity is required"},
+		{"sla target with unknown stage", "sla:\n  targets:\n    - severity: high\n      stage: disclosure\n      within: 1d\n", "sla.targets[0]: stage must be one of"},
+		{"invalid duration", "scrape_interval: soon\n", "not a valid duration"},
+		{"invalid YAML", "organizations: [\n", "parsing config file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := config.LoadFile(writeFile(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadFile() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadFileEmpty(t *testing.T) {
+	if _, err := config.LoadFile(writeFile(t, "")); err != nil {
+		t.Errorf("LoadFile() error = %v, want an empty file to be valid", err)
+	}
+}
+
+func TestNewPrecedence(t *testing.T) {
+	flagCredentials := config.Credentials{APIUser: "flag-user", APIPassword: "flag-password"}
+
+	tests := []struct {
+		name  string
+		args  []string
+		file  string
+		check func(t *testing.T, cfg *config.Config)
+	}{
+		{
+			name: "flags without file",
+			args: []string{"--org-id", "1", "--scrape-interval", "30"},
+			check: func(t *testing.T, cfg *config.Config) {
+				want := []config.Organization{{ID: "1", Credentials: flagCredentials}}
+				if !reflect.DeepEqual(cfg.Organizations, want) {
+					t.Errorf("Organizations = %+v, want %+v", cfg.Organizations, want)
+				}
+				if cfg.ScrapeInterval != 30*time.Second || cfg.ReadyMaxScrapeAge != 90*time.Second {
+					t.Errorf("ScrapeInterval, ReadyMaxScrapeAge = %s, %s, want 30s, 1m30s", cfg.ScrapeInterval, cfg.ReadyMaxScrapeAge)
+				}
+			},
+		},
+		{
+			name: "file overrides flags",
+			args: []string{"--org-id", "1", "--scrape-interval", "30", "--api-rate-limit", "2", "--no-collector.reports"},
+			file: "scrape_interval: 2m\napi:\n  rate_limit: 0\ncollectors:\n  reports:\n    enabled: true\n",
+			check: func(t *testing.T, cfg *config.Config) {
+				if cfg.ScrapeInterval != 2*time.Minute {
+					t.Errorf("ScrapeInterval = %s, want 2m", cfg.ScrapeInterval)
+				}
+				if cfg.RateLimit != 0 {
+					t.Errorf("RateLimit = %v, want 0 from the file", cfg.RateLimit)
+				}
+				if !cfg.Collectors["reports"] {
+					t.Error("reports collector disabled, want it enabled by the file")
+				}
+			},
+		},
+		{
+			name: "flags are kept for options the file leaves out",
+			args: []string{"--org-id", "1", "--scrape-concurrency", "8", "--api-rate-limit", "2"},
+			file: "scrape_interval: 2m\n",
+			check: func(t *testing.T, cfg *config.Config) {
+				if cfg.ScrapeConcurrency != 8 || cfg.RateLimit != 2 {
+					t.Errorf("ScrapeConcurrency, RateLimit = %d, %v, want 8, 2 from the flags", cfg.ScrapeConcurrency, cfg.RateLimit)
+				}
+			},
+		},
+		{
+			name: "file organizations replace the flag organization and default to flag credentials",
+			args: []string{"--org-id", "1"},
+			file: `
+organizations:
+  - id: "2"
+  - id: "3"
+    api_user: own-user
+    api_password: own-password
+  - id: "4"
+    api_user: own-user
+`,
+			check: func(t *testing.T, cfg *config.Config) {
+				want := []config.Organization{
+					{ID: "2", Credentials: flagCredentials},
+					{ID: "3", Credentials: config.Credentials{APIUser: "own-user", APIPassword: "own-password"}},
+					{ID: "4", Credentials: config.Credentials{APIUser: "own-user", APIPassword: "flag-password"}},
+				}
+				if !reflect.DeepEqual(cfg.Organizations, want) {
+					t.Errorf("Organizations = %+v, want %+v", cfg.Organizations, want)
+				}
+			},
+		},
+		{
+			name: "modules default to flag credentials",
+			file: `
+modules:
+  default: {}
+  reports:
+    api_user: own-user
+    api_password: own-password
+    collectors: [reports]
+`,
+			check: func(t *testing.T, cfg *config.Config) {
+				want := map[string]config.Module{
+					"default": {Credentials: flagCredentials},
+					"reports": {Credentials: config.Credentials{APIUser: "own-user", APIPassword: "own-password"}, Collectors: []string{"reports"}},
+				}
+				if !reflect.DeepEqual(cfg.Modules, want) {
+					t.Errorf("Modules = %+v, want %+v", cfg.Modules, want)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := append([]string{"--api-user", flagCredentials.APIUser, "--api-password", flagCredentials.APIPassword}, tt.args...)
+			cfg, err := newConfig(t, tt.file, args...)
+			if err != nil {
+				t.Fatalf("config.New() error = %v", err)
+			}
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestNewInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		file    string
+		wantErr string
+	}{
+		{"no organization", []string{"--api-user", "u", "--api-password", "p"}, "", "organization ID is required"},
+		{"no credentials", []string{"--org-id", "1"}, "", "API user is required"},
+		{"file organization without credentials", nil, "organizations:\n  - id: \"1\"\n", `organization "1": HackerOne API user is required`},
+		{"duplicate organization", []string{"--api-user", "u", "--api-password", "p"}, "organizations:\n  - id: \"1\"\n  - id: \"1\"\n", `duplicate HackerOne organization "1"`},
+		{"record and replay", []string{"--org-id", "1", "--api-user", "u", "--api-password", "p", "--api.record-dir", "a", "--api.replay-dir", "b"}, "", "mutually exclusive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newConfig(t, tt.file, tt.args...)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("config.New() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"context"
+
+	"github.com/dirsigler/hackerone-exporter/pkg/config"
+	"github.com/dirsigler/hackerone-exporter/pkg/metrics"
+	"github.com/dirsigler/hackerone-exporter/pkg/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector("assets", defaultEnabled, newAssetsCollector)
+}
+
+// assetsCollector counts the assets of the organization
+type assetsCollector struct{}
+
+func newAssetsCollector(_ *config.Config) Collector {
+	return &assetsCollector{}
+}
+
+type assetsResult struct {
+	assets int
+}
+
+// Update implements Collector
+func (c *assetsCollector) Update(ctx context.Context, s *scrapeContext) (Result, error) {
+	var assets *types.Assets
+	err := s.limit(ctx, func() (err error) {
+		assets, err = s.client.GetAssets(ctx, s.orgID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &assetsResult{assets: len(assets.Data)}, nil
+}
+
+// Collect implements Result
+func (r *assetsResult) Collect(m *metrics.Metrics, ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(m.AssetsTotal, prometheus.GaugeValue, float64(r.assets))
+}
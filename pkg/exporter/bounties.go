@@ -0,0 +1,105 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/dirsigler/hackerone-exporter/pkg/client"
+	"github.com/dirsigler/hackerone-exporter/pkg/config"
+	"github.com/dirsigler/hackerone-exporter/pkg/metrics"
+	"github.com/dirsigler/hackerone-exporter/pkg/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector("bounties", defaultDisabled, newBountiesCollector)
+}
+
+// bountiesCollector exposes the bounties awarded on reports and the billing
+// balance of every program
+type bountiesCollector struct{}
+
+func newBountiesCollector(_ *config.Config) Collector {
+	return &bountiesCollector{}
+}
+
+type bountiesResult struct {
+	amounts  *metrics.GaugeSet
+	counts   *metrics.GaugeSet
+	balances *metrics.GaugeSet
+}
+
+// Update implements Collector
+func (c *bountiesCollector) Update(ctx context.Context, s *scrapeContext) (Result, error) {
+	reports, err := fetchPerProgram(ctx, s, s.reports)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tokens without billing permission cannot read balances, which must
+	// not keep the bounties of the reports from being exported
+	balances, err := fetchPerProgram(ctx, s, func(ctx context.Context, p program) (*types.ProgramBalance, error) {
+		balance, err := s.client.GetProgramBalance(ctx, p.id)
+		var forbidden *client.ForbiddenError
+		if errors.As(err, &forbidden) {
+			s.logger.Debug("no permission to read program balance", slog.String("program", p.handle))
+			return nil, nil
+		}
+		return balance, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &bountiesResult{
+		amounts:  metrics.NewGaugeSet(),
+		counts:   metrics.NewGaugeSet(),
+		balances: metrics.NewGaugeSet(),
+	}
+	for _, p := range reports {
+		for _, report := range p.data.Data {
+			severity := labelValue(report.Relationships.Severity.Data.Attributes.Rating)
+
+			for _, bounty := range report.Relationships.Bounties.Data {
+				currency := bounty.Attributes.AwardedCurrency
+				if currency == "" {
+					currency = "unknown"
+				}
+
+				amount := bounty.Attributes.AwardedAmount + bounty.Attributes.AwardedBonusAmount
+				result.amounts.Add(float64(amount), p.program.handle, severity, currency)
+				result.counts.Add(1, p.program.handle, severity)
+			}
+		}
+	}
+
+	for _, p := range balances {
+		if p.data == nil {
+			continue
+		}
+		result.balances.Set(float64(p.data.Data.Attributes.Balance), p.program.handle)
+	}
+	return result, nil
+}
+
+// Collect implements Result
+func (r *bountiesResult) Collect(m *metrics.Metrics, ch chan<- prometheus.Metric) {
+	r.amounts.Collect(ch, m.BountiesAwardedAmount)
+	r.counts.Collect(ch, m.BountiesAwardedCount)
+	r.balances.Collect(ch, m.ProgramBalance)
+}
@@ -0,0 +1,218 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+
+	"github.com/dirsigler/hackerone-exporter/pkg/client"
+	"github.com/dirsigler/hackerone-exporter/pkg/config"
+	"github.com/dirsigler/hackerone-exporter/pkg/metrics"
+	"github.com/dirsigler/hackerone-exporter/pkg/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	defaultEnabled  = true
+	defaultDisabled = false
+)
+
+var (
+	factories              = make(map[string]func(cfg *config.Config) Collector)
+	collectorDefaultStates = make(map[string]bool)
+)
+
+// Collector fetches a single HackerOne resource family during a scrape
+type Collector interface {
+	// Update fetches the collector's resources from the HackerOne API. When
+	// it fails the exporter keeps serving the collector's last good result.
+	Update(ctx context.Context, s *scrapeContext) (Result, error)
+}
+
+// Result is the immutable outcome of a Collector update. It is rendered into
+// const metrics on every Prometheus scrape until the next update replaces it.
+type Result interface {
+	Collect(m *metrics.Metrics, ch chan<- prometheus.Metric)
+}
+
+// registerCollector makes a collector available under the given name. It is
+// meant to be called from the init function of each collector file.
+func registerCollector(name string, isDefaultEnabled bool, factory func(cfg *config.Config) Collector) {
+	factories[name] = factory
+	collectorDefaultStates[name] = isDefaultEnabled
+}
+
+// collectorNames returns the names of all registered collectors in a stable order
+func collectorNames() []string {
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CollectorFlags returns a --collector.<name> and --no-collector.<name> flag
+// for every registered collector
+func CollectorFlags() []cli.Flag {
+	var flags []cli.Flag
+	for _, name := range collectorNames() {
+		state := "disabled"
+		if collectorDefaultStates[name] {
+			state = "enabled"
+		}
+
+		flags = append(flags,
+			&cli.BoolFlag{
+				Name:     "collector." + name,
+				Usage:    fmt.Sprintf("Enable the %s collector (default: %s)", name, state),
+				Value:    collectorDefaultStates[name],
+				Category: "Collectors",
+			},
+			&cli.BoolFlag{
+				Name:     "no-collector." + name,
+				Usage:    fmt.Sprintf("Disable the %s collector", name),
+				Category: "Collectors",
+			},
+		)
+	}
+	return flags
+}
+
+// newCollectors instantiates every collector enabled in the configuration.
+// Collectors the configuration does not mention fall back to their default state.
+func newCollectors(cfg *config.Config) (map[string]Collector, error) {
+	for name := range cfg.Collectors {
+		if _, ok := factories[name]; !ok {
+			return nil, fmt.Errorf("unknown collector %q", name)
+		}
+	}
+
+	collectors := make(map[string]Collector)
+	for _, name := range collectorNames() {
+		isEnabled, ok := cfg.Collectors[name]
+		if !ok {
+			isEnabled = collectorDefaultStates[name]
+		}
+		if isEnabled {
+			collectors[name] = factories[name](cfg)
+		}
+	}
+	return collectors, nil
+}
+
+// program identifies a HackerOne program the per-program collectors iterate over
+type program struct {
+	id     string
+	handle string
+}
+
+// scrapeContext holds the state shared by all collectors during one scrape
+type scrapeContext struct {
+	client   client.HackerOneAPI
+	orgID    string
+	programs []program
+	logger   *slog.Logger
+
+	// programsErr is set when the programs could not be fetched. programs
+	// then holds the programs known from the previous scrape, if any.
+	programsErr error
+
+	// sem bounds the number of concurrent API calls across all collectors
+	sem chan struct{}
+
+	reportsMu    sync.Mutex
+	reportsCache map[string]*cachedReports
+}
+
+// cachedReports holds the reports of a program fetched during a scrape
+type cachedReports struct {
+	once    sync.Once
+	reports *types.Reports
+	err     error
+}
+
+// reports returns the reports of a program. They are fetched at most once per
+// scrape so that collectors sharing them do not repeat the API calls.
+func (s *scrapeContext) reports(ctx context.Context, p program) (*types.Reports, error) {
+	s.reportsMu.Lock()
+	if s.reportsCache == nil {
+		s.reportsCache = make(map[string]*cachedReports)
+	}
+	cached, ok := s.reportsCache[p.handle]
+	if !ok {
+		cached = &cachedReports{}
+		s.reportsCache[p.handle] = cached
+	}
+	s.reportsMu.Unlock()
+
+	cached.once.Do(func() {
+		cached.reports, cached.err = s.client.GetAllReports(ctx, p.handle)
+	})
+	return cached.reports, cached.err
+}
+
+// limit runs fn once a concurrency slot is available
+func (s *scrapeContext) limit(ctx context.Context, fn func() error) error {
+	select {
+	case s.sem <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-s.sem }()
+
+	return fn()
+}
+
+// programResources pairs a program with a resource fetched for it
+type programResources[T any] struct {
+	program program
+	data    *T
+}
+
+// fetchPerProgram calls fetch concurrently for every program of the scrape and
+// returns the results in program order. Failures of individual programs are
+// joined into the returned error.
+func fetchPerProgram[T any](ctx context.Context, s *scrapeContext, fetch func(ctx context.Context, p program) (*T, error)) ([]programResources[T], error) {
+	if s.programs == nil && s.programsErr != nil {
+		return nil, s.programsErr
+	}
+
+	results := make([]programResources[T], len(s.programs))
+	errs := make([]error, len(s.programs))
+
+	var wg sync.WaitGroup
+	for i, p := range s.programs {
+		results[i].program = p
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = s.limit(ctx, func() (err error) {
+				results[i].data, err = fetch(ctx, p)
+				return err
+			})
+		}()
+	}
+	wg.Wait()
+
+	return results, errors.Join(errs...)
+}
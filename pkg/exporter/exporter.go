@@ -0,0 +1,410 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package exporter scrapes HackerOne organizations in the background and
+// serves the results as Prometheus metrics. Exporter implements
+// prometheus.Collector, so it can be embedded into other binaries by
+// registering it and calling Run.
+package exporter
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dirsigler/hackerone-exporter/pkg/client"
+	"github.com/dirsigler/hackerone-exporter/pkg/config"
+	"github.com/dirsigler/hackerone-exporter/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Exporter manages the HackerOne metrics collection
+type Exporter struct {
+	logger          *slog.Logger
+	instrumentation *metrics.Instrumentation
+	newAPI          APIFactory
+	state           atomic.Pointer[state]
+	snapshot        atomic.Pointer[snapshot]
+
+	// reloaded wakes up Run after the configuration was reloaded
+	reloaded chan struct{}
+}
+
+// state holds everything the exporter derives from its configuration. It is
+// replaced as a whole when the configuration is reloaded.
+type state struct {
+	config     *config.Config
+	collectors map[string]Collector
+	targets    []*target
+	modules    map[string]*module
+}
+
+// target is a HackerOne organization scraped with its own credentials
+type target struct {
+	orgID   string
+	client  client.HackerOneAPI
+	metrics *metrics.Metrics
+}
+
+// snapshot is the immutable result of a single scrape of the HackerOne API.
+// It is replaced as a whole after every scrape and never modified afterwards.
+type snapshot struct {
+	state *state
+	orgs  map[string]*orgSnapshot
+}
+
+// orgSnapshot is the result of scraping a single organization. Collectors
+// that failed keep the result of their last successful update.
+type orgSnapshot struct {
+	programs   []program
+	results    map[string]collectorResult
+	statuses   map[string]collectorStatus
+	up         bool
+	err        error
+	finishedAt time.Time
+
+	// lastSuccess is the end of the last scrape that reached the API
+	lastSuccess time.Time
+}
+
+// collectorStatus is the outcome of a collector's update during a scrape
+type collectorStatus struct {
+	success  bool
+	duration time.Duration
+
+	// lastErr is the error of the collector's most recent failed update
+	lastErr error
+}
+
+// collectorResult is the last successful result of a collector
+type collectorResult struct {
+	result    Result
+	updatedAt time.Time
+}
+
+// New creates a new HackerOne exporter. Options left unset in cfg take the
+// defaults of the command line flags. By default organizations are scraped
+// through the HackerOne API, see WithAPI to use another backend.
+func New(cfg *config.Config, logger *slog.Logger, opts ...Option) (*Exporter, error) {
+	e := &Exporter{
+		logger:          logger,
+		instrumentation: metrics.NewInstrumentation(),
+		newAPI:          NewClient,
+		reloaded:        make(chan struct{}, 1),
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	st, err := e.newState(cfg)
+	if err != nil {
+		return nil, err
+	}
+	e.state.Store(st)
+	return e, nil
+}
+
+// newState fills the defaults of cfg, validates it and builds the collectors
+// and the API client of every organization it describes
+func (e *Exporter) newState(cfg *config.Config) (*state, error) {
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if err := validateReportLabels(cfg.ReportLabels); err != nil {
+		return nil, err
+	}
+
+	collectors, err := newCollectors(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	modules, err := newModules(cfg, collectors)
+	if err != nil {
+		return nil, err
+	}
+
+	st := &state{
+		config:     cfg,
+		collectors: collectors,
+		modules:    modules,
+	}
+	for _, org := range cfg.Organizations {
+		prometheusMetrics := metrics.New(org.ID, cfg.ReportLabels, e.instrumentation)
+		st.targets = append(st.targets, &target{
+			orgID:   org.ID,
+			client:  e.newAPI(cfg, org, prometheusMetrics, newLimiter(cfg), e.logger.With(slog.String("organization_id", org.ID))),
+			metrics: prometheusMetrics,
+		})
+	}
+	return st, nil
+}
+
+// newLimiter creates the rate limiter of one set of API credentials
+func newLimiter(cfg *config.Config) *client.Limiter {
+	return client.NewLimiter(cfg.RateLimit, int(cfg.RateBurst))
+}
+
+// Reload applies a new configuration. An invalid configuration is rejected
+// and the exporter keeps running with the previous one. Otherwise the next
+// scrape starts right away with the new configuration.
+func (e *Exporter) Reload(cfg *config.Config) error {
+	st, err := e.newState(cfg)
+	if err != nil {
+		return err
+	}
+	e.state.Store(st)
+
+	select {
+	case e.reloaded <- struct{}{}:
+	default:
+	}
+
+	e.logger.Info("Configuration reloaded",
+		slog.Any("organizations", e.Organizations()),
+		slog.Any("collectors", e.Collectors()))
+	return nil
+}
+
+// Collectors returns the names of the enabled collectors
+func (e *Exporter) Collectors() []string {
+	st := e.state.Load()
+
+	var names []string
+	for _, name := range collectorNames() {
+		if _, ok := st.collectors[name]; ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Organizations returns the IDs of the scraped organizations
+func (e *Exporter) Organizations() []string {
+	var ids []string
+	for _, t := range e.state.Load().targets {
+		ids = append(ids, t.orgID)
+	}
+	return ids
+}
+
+// Run scrapes the HackerOne API once immediately and then on every
+// ScrapeInterval until ctx is cancelled. Prometheus scrapes are served from
+// the most recent snapshot and never trigger API requests themselves.
+func (e *Exporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.state.Load().config.ScrapeInterval)
+	defer ticker.Stop()
+
+	for {
+		e.scrape(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-e.reloaded:
+			ticker.Reset(e.state.Load().config.ScrapeInterval)
+		}
+	}
+}
+
+// scrape scrapes every organization concurrently and stores their results as
+// the current snapshot.
+func (e *Exporter) scrape(ctx context.Context) {
+	st := e.state.Load()
+
+	ctx, cancel := context.WithTimeout(ctx, st.config.ScrapeInterval)
+	defer cancel()
+
+	e.logger.Info("Starting HackerOne metrics scrape")
+
+	// Data fetched with a previous configuration may no longer match the
+	// enabled collectors or the labels of their metrics. The time of the
+	// last successful scrape stays valid across reloads.
+	previous := e.snapshot.Load()
+	if previous == nil {
+		previous = &snapshot{}
+	}
+	lastSuccess := make(map[string]time.Time, len(previous.orgs))
+	for id, org := range previous.orgs {
+		lastSuccess[id] = org.lastSuccess
+	}
+	if previous.state != st {
+		previous = &snapshot{}
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		orgs = make(map[string]*orgSnapshot, len(st.targets))
+	)
+	for _, t := range st.targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			current := e.scrapeOrganization(ctx, st.collectors, st.config.ScrapeConcurrency, t, previous.orgs[t.orgID])
+			if !current.up {
+				current.lastSuccess = lastSuccess[t.orgID]
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			orgs[t.orgID] = current
+		}()
+	}
+	wg.Wait()
+
+	e.snapshot.Store(&snapshot{state: st, orgs: orgs})
+
+	e.logger.Info("HackerOne metrics scrape completed")
+}
+
+// scrapeOrganization runs the given collectors concurrently against a single
+// organization. Failed collectors fall back to their result in previous,
+// which is nil on the first scrape.
+func (e *Exporter) scrapeOrganization(ctx context.Context, collectors map[string]Collector, concurrency int64, t *target, previous *orgSnapshot) *orgSnapshot {
+	timer := prometheus.NewTimer(t.metrics.ScrapeDuration)
+	defer timer.ObserveDuration()
+
+	if previous == nil {
+		previous = &orgSnapshot{}
+	}
+
+	logger := e.logger.With(slog.String("organization_id", t.orgID))
+	s := &scrapeContext{
+		client: t.client,
+		orgID:  t.orgID,
+		logger: logger,
+		sem:    make(chan struct{}, max(concurrency, 1)),
+	}
+
+	programs, err := t.client.GetPrograms(ctx)
+	if err != nil {
+		t.metrics.ScrapeErrors.Inc()
+		logger.Error("getting programs", slog.String("error", err.Error()))
+
+		// Keep scraping the programs we knew about during the last scrape
+		s.programs = previous.programs
+		s.programsErr = err
+	} else {
+		for _, p := range programs.Data {
+			s.programs = append(s.programs, program{id: p.ID, handle: p.Attributes.Handle})
+		}
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		results  = make(map[string]collectorResult, len(collectors))
+		statuses = make(map[string]collectorStatus, len(collectors))
+	)
+	for name, c := range collectors {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			start := time.Now()
+			result, err := c.Update(ctx, s)
+			status := collectorStatus{success: err == nil, duration: time.Since(start), lastErr: err}
+			if err == nil {
+				status.lastErr = previous.statuses[name].lastErr
+			}
+
+			current, ok := collectorResult{result: result, updatedAt: time.Now()}, true
+			if err != nil {
+				t.metrics.ScrapeErrors.Inc()
+				logger.Error("collector failed, keeping last known good data",
+					slog.String("collector", name),
+					slog.String("error", err.Error()))
+
+				current, ok = previous.results[name]
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			statuses[name] = status
+			if ok {
+				results[name] = current
+			}
+		}()
+	}
+	wg.Wait()
+
+	current := &orgSnapshot{
+		programs:   s.programs,
+		results:    results,
+		statuses:   statuses,
+		up:         s.programsErr == nil,
+		err:        s.programsErr,
+		finishedAt: time.Now(),
+	}
+	if current.up {
+		current.lastSuccess = current.finishedAt
+	}
+	return current
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// that can be collected by this Collector to the provided channel.
+func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
+	for _, t := range e.state.Load().targets {
+		t.metrics.Describe(ch)
+	}
+	e.instrumentation.Describe(ch)
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+// It only renders the latest snapshot as const metrics and never calls the
+// HackerOne API, so concurrent scrapes never observe partial state.
+func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
+	if snap := e.snapshot.Load(); snap != nil {
+		for _, t := range snap.state.targets {
+			if org, ok := snap.orgs[t.orgID]; ok {
+				org.collect(t.metrics, ch)
+			}
+		}
+	}
+
+	e.instrumentation.Collect(ch)
+}
+
+// collect renders the organization's results and the status of its scrape
+func (o *orgSnapshot) collect(m *metrics.Metrics, ch chan<- prometheus.Metric) {
+	for name, current := range o.results {
+		current.result.Collect(m, ch)
+		ch <- prometheus.MustNewConstMetric(m.DataAge, prometheus.GaugeValue, time.Since(current.updatedAt).Seconds(), name)
+	}
+
+	for name, status := range o.statuses {
+		ch <- prometheus.MustNewConstMetric(m.CollectorSuccess, prometheus.GaugeValue, boolToFloat(status.success), name)
+		ch <- prometheus.MustNewConstMetric(m.CollectorDuration, prometheus.GaugeValue, status.duration.Seconds(), name)
+	}
+
+	ch <- prometheus.MustNewConstMetric(m.Up, prometheus.GaugeValue, boolToFloat(o.up))
+	if !o.lastSuccess.IsZero() {
+		ch <- prometheus.MustNewConstMetric(m.LastScrapeTime, prometheus.GaugeValue, float64(o.lastSuccess.UnixNano())/1e9)
+	}
+}
+
+// boolToFloat converts a boolean into the 0 or 1 of a Prometheus gauge
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,171 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dirsigler/hackerone-exporter/internal/h1fake"
+	"github.com/dirsigler/hackerone-exporter/pkg/client"
+	"github.com/dirsigler/hackerone-exporter/pkg/config"
+	"github.com/dirsigler/hackerone-exporter/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestScrapeKeepsLastKnownGoodData(t *testing.T) {
+	fake := h1fake.New(h1fake.Minimal(2))
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	logger := slog.New(slog.DiscardHandler)
+	api := client.New(client.Options{
+		BaseURL: server.URL,
+		Metrics: metrics.New("42", nil, metrics.NewInstrumentation()),
+	}, logger)
+
+	cfg := &config.Config{
+		Organizations: []config.Organization{{
+			ID:          "42",
+			Credentials: config.Credentials{APIUser: "hacker", APIPassword: "secret"},
+		}},
+		ScrapeConcurrency: 1,
+		Collectors: map[string]bool{
+			"assets":            true,
+			"weaknesses":        true,
+			"invited_hackers":   false,
+			"programs":          false,
+			"reporters":         false,
+			"reports":           false,
+			"structured_scopes": false,
+		},
+	}
+	e, err := New(cfg, logger, WithAPI(api))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	const header = `
+# HELP hackerone_assets_total Total number of HackerOne Assets
+# TYPE hackerone_assets_total gauge
+# HELP hackerone_scrape_collector_success Whether a collector succeeded during the last scrape
+# TYPE hackerone_scrape_collector_success gauge
+# HELP hackerone_up Whether the HackerOne API could be reached during the last scrape
+# TYPE hackerone_up gauge
+# HELP hackerone_weaknesses_total Total number of HackerOne Weaknesses
+# TYPE hackerone_weaknesses_total gauge
+`
+	names := []string{"hackerone_assets_total", "hackerone_scrape_collector_success", "hackerone_up", "hackerone_weaknesses_total"}
+
+	steps := []struct {
+		name   string
+		update func()
+		want   string
+	}{
+		{
+			name:   "all collectors succeed",
+			update: func() {},
+			want: `
+hackerone_assets_total{organization_id="42"} 2
+hackerone_scrape_collector_success{collector="assets",organization_id="42"} 1
+hackerone_scrape_collector_success{collector="weaknesses",organization_id="42"} 1
+hackerone_up{organization_id="42"} 1
+hackerone_weaknesses_total{id="10",name="Stored XSS",organization_id="42"} 1
+`,
+		},
+		{
+			name: "a failed collector keeps its last result",
+			update: func() {
+				fake.SetData(h1fake.Minimal(3))
+				fake.Fail("/v1/organizations/42/assets", http.StatusForbidden)
+			},
+			want: `
+hackerone_assets_total{organization_id="42"} 2
+hackerone_scrape_collector_success{collector="assets",organization_id="42"} 0
+hackerone_scrape_collector_success{collector="weaknesses",organization_id="42"} 1
+hackerone_up{organization_id="42"} 1
+hackerone_weaknesses_total{id="10",name="Stored XSS",organization_id="42"} 1
+`,
+		},
+		{
+			name: "programs of the last scrape are kept while they cannot be listed",
+			update: func() {
+				fake.Fail("/v1/organizations/42/assets", 0)
+				fake.Fail("/v1/me/programs", http.StatusForbidden)
+			},
+			want: `
+hackerone_assets_total{organization_id="42"} 3
+hackerone_scrape_collector_success{collector="assets",organization_id="42"} 1
+hackerone_scrape_collector_success{collector="weaknesses",organization_id="42"} 1
+hackerone_up{organization_id="42"} 0
+hackerone_weaknesses_total{id="10",name="Stored XSS",organization_id="42"} 1
+`,
+		},
+	}
+
+	var lastSuccess time.Time
+	for _, step := range steps {
+		step.update()
+		e.scrape(context.Background())
+
+		if err := testutil.CollectAndCompare(e, strings.NewReader(header+step.want), names...); err != nil {
+			t.Errorf("%s: %v", step.name, err)
+		}
+
+		org := e.snapshot.Load().orgs["42"]
+		if org.up {
+			lastSuccess = org.lastSuccess
+		} else if !org.lastSuccess.Equal(lastSuccess) {
+			t.Errorf("%s: lastSuccess = %v, want the previous successful scrape at %v", step.name, org.lastSuccess, lastSuccess)
+		}
+	}
+
+	if got := fake.Requests("/v1/me/programs"); got != len(steps) {
+		t.Errorf("program requests = %d, want %d", got, len(steps))
+	}
+}
+
+func TestNewAppliesDefaults(t *testing.T) {
+	logger := slog.New(slog.DiscardHandler)
+
+	cfg := &config.Config{
+		Organizations: []config.Organization{{
+			ID:          "42",
+			Credentials: config.Credentials{APIUser: "hacker", APIPassword: "secret"},
+		}},
+	}
+	e, err := New(cfg, logger)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	got := e.state.Load().config
+	if got.ScrapeInterval != time.Minute || got.ScrapeConcurrency != 4 || got.ReadyMaxScrapeAge != 3*time.Minute {
+		t.Errorf("ScrapeInterval, ScrapeConcurrency, ReadyMaxScrapeAge = %s, %d, %s, want the defaults of the flags",
+			got.ScrapeInterval, got.ScrapeConcurrency, got.ReadyMaxScrapeAge)
+	}
+
+	if _, err := New(&config.Config{}, logger); err == nil {
+		t.Error("New() without organizations succeeded, want a validation error")
+	}
+	if err := e.Reload(&config.Config{Organizations: []config.Organization{{ID: "42"}}}); err == nil {
+		t.Error("Reload() without credentials succeeded, want a validation error")
+	}
+}
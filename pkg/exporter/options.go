@@ -0,0 +1,87 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/dirsigler/hackerone-exporter/pkg/client"
+	"github.com/dirsigler/hackerone-exporter/pkg/config"
+	"github.com/dirsigler/hackerone-exporter/pkg/metrics"
+)
+
+// APIFactory creates the HackerOne API client of an organization. It is
+// called for every organization whenever a configuration is applied and for
+// every probe, which uses the credentials of the probe module. Clients
+// should report their requests to m and pace them with limiter, which the
+// probes of a module share.
+type APIFactory func(cfg *config.Config, org config.Organization, m *metrics.Metrics, limiter *client.Limiter, logger *slog.Logger) client.HackerOneAPI
+
+// Option customizes an Exporter
+type Option func(*Exporter)
+
+// WithAPI scrapes every organization through api instead of the HackerOne
+// API. API requests are only instrumented if api does so itself.
+func WithAPI(api client.HackerOneAPI) Option {
+	return WithAPIFactory(func(*config.Config, config.Organization, *metrics.Metrics, *client.Limiter, *slog.Logger) client.HackerOneAPI {
+		return api
+	})
+}
+
+// WithAPIFactory creates the API clients of organizations with newAPI.
+// Decorators can wrap the clients returned by NewClient.
+func WithAPIFactory(newAPI APIFactory) Option {
+	return func(e *Exporter) {
+		e.newAPI = newAPI
+	}
+}
+
+// NewClient is the default APIFactory. It returns a client of the HackerOne
+// API configured by cfg.
+func NewClient(cfg *config.Config, org config.Organization, m *metrics.Metrics, limiter *client.Limiter, logger *slog.Logger) client.HackerOneAPI {
+	return client.New(client.Options{
+		Username:     org.APIUser,
+		Password:     org.APIPassword,
+		UsernameFile: org.APIUserFile,
+		PasswordFile: org.APIPasswordFile,
+		BaseURL:      cfg.APIURL,
+		PageSize:     int(cfg.PageSize),
+		MaxPages:     int(cfg.MaxPages),
+		Limiter:      limiter,
+		Metrics:      m,
+		RecordDir:    orgDir(cfg.RecordDir, org.ID),
+		ReplayDir:    orgDir(cfg.ReplayDir, org.ID),
+	}, logger)
+}
+
+// orgDir returns the subdirectory of dir holding the fixtures of the
+// organization orgID, so that organizations sharing endpoints such as
+// /v1/me/programs do not overwrite each other's responses. Probe targets are
+// user input, so orgID must not be able to escape dir.
+func orgDir(dir, orgID string) string {
+	if dir == "" {
+		return ""
+	}
+	safe := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, orgID)
+	return filepath.Join(dir, safe)
+}
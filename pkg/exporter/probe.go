@@ -0,0 +1,124 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/dirsigler/hackerone-exporter/pkg/client"
+	"github.com/dirsigler/hackerone-exporter/pkg/config"
+	"github.com/dirsigler/hackerone-exporter/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// module is a probe module of the configuration with its collectors
+type module struct {
+	config     config.Module
+	collectors map[string]Collector
+
+	// limiter paces the API requests of all probes of the module, which
+	// share its credentials and therefore its API quota
+	limiter *client.Limiter
+}
+
+// newModules builds the collectors of every probe module. Modules that do
+// not list collectors use the enabled ones.
+func newModules(cfg *config.Config, enabled map[string]Collector) (map[string]*module, error) {
+	modules := make(map[string]*module, len(cfg.Modules))
+	for name, mc := range cfg.Modules {
+		m := &module{config: mc, collectors: enabled, limiter: newLimiter(cfg)}
+		if len(mc.Collectors) > 0 {
+			m.collectors = make(map[string]Collector, len(mc.Collectors))
+			for _, collector := range mc.Collectors {
+				factory, ok := factories[collector]
+				if !ok {
+					return nil, fmt.Errorf("module %q: unknown collector %q", name, collector)
+				}
+				m.collectors[collector] = factory(cfg)
+			}
+		}
+		modules[name] = m
+	}
+	return modules, nil
+}
+
+// Probe scrapes the organization orgID with the credentials and collectors of
+// the named module and returns a registry holding the resulting metrics. The
+// HackerOne API is called synchronously, so ctx should carry the timeout of
+// the Prometheus scrape. An error is only returned for unknown modules; a
+// failed scrape is reported by hackerone_probe_success.
+func (e *Exporter) Probe(ctx context.Context, orgID, moduleName string) (*prometheus.Registry, error) {
+	st := e.state.Load()
+
+	mod, ok := st.modules[moduleName]
+	if !ok {
+		return nil, fmt.Errorf("unknown module %q", moduleName)
+	}
+
+	probeSuccess := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name:      "probe_success",
+		Help:      "Whether every collector of the probe succeeded",
+		Namespace: "hackerone",
+	})
+	probeDuration := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name:      "probe_duration_seconds",
+		Help:      "Duration of the probe in seconds",
+		Namespace: "hackerone",
+	})
+
+	// Probes get their own instrumentation so that the API requests of
+	// arbitrary targets are only exposed with the probe's response
+	instrumentation := metrics.NewInstrumentation()
+	m := metrics.New(orgID, st.config.ReportLabels, instrumentation)
+	org := config.Organization{ID: orgID, Credentials: mod.config.Credentials}
+	t := &target{
+		orgID:   orgID,
+		client:  e.newAPI(st.config, org, m, mod.limiter, e.logger.With(slog.String("organization_id", orgID))),
+		metrics: m,
+	}
+
+	start := time.Now()
+	result := e.scrapeOrganization(ctx, mod.collectors, st.config.ScrapeConcurrency, t, nil)
+	probeDuration.Set(time.Since(start).Seconds())
+
+	success := result.up
+	for _, status := range result.statuses {
+		success = success && status.success
+	}
+	probeSuccess.Set(boolToFloat(success))
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(probeSuccess, probeDuration, instrumentation, &probeCollector{org: result, metrics: m})
+	return registry, nil
+}
+
+// probeCollector renders the result of a single probe
+type probeCollector struct {
+	org     *orgSnapshot
+	metrics *metrics.Metrics
+}
+
+// Describe implements prometheus.Collector
+func (c *probeCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.metrics.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (c *probeCollector) Collect(ch chan<- prometheus.Metric) {
+	c.org.collect(c.metrics, ch)
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"context"
+
+	"github.com/dirsigler/hackerone-exporter/pkg/config"
+	"github.com/dirsigler/hackerone-exporter/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector("programs", defaultEnabled, newProgramsCollector)
+}
+
+// programsCollector exposes the programs of the organization. The programs
+// themselves are fetched once per scrape since every per-program collector
+// depends on them.
+type programsCollector struct{}
+
+func newProgramsCollector(_ *config.Config) Collector {
+	return &programsCollector{}
+}
+
+type programsResult struct {
+	programs *metrics.GaugeSet
+}
+
+// Update implements Collector
+func (c *programsCollector) Update(_ context.Context, s *scrapeContext) (Result, error) {
+	if s.programsErr != nil {
+		return nil, s.programsErr
+	}
+
+	programs := metrics.NewGaugeSet()
+	for _, p := range s.programs {
+		programs.Add(1, p.handle)
+	}
+
+	return &programsResult{programs: programs}, nil
+}
+
+// Collect implements Result
+func (r *programsResult) Collect(m *metrics.Metrics, ch chan<- prometheus.Metric) {
+	r.programs.Collect(ch, m.ProgramsTotal)
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"context"
+
+	"github.com/dirsigler/hackerone-exporter/pkg/config"
+	"github.com/dirsigler/hackerone-exporter/pkg/metrics"
+	"github.com/dirsigler/hackerone-exporter/pkg/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector("reporters", defaultEnabled, newReportersCollector)
+}
+
+// reportersCollector exposes the hackers that reported to every program.
+// Per-user series are opt-in since they grow with the number of reporters.
+type reportersCollector struct {
+	perUser bool
+}
+
+func newReportersCollector(cfg *config.Config) Collector {
+	return &reportersCollector{perUser: cfg.ReportersPerUser}
+}
+
+type reportersResult struct {
+	reporters   *metrics.GaugeSet
+	reputations *metrics.HistogramSet
+
+	// The per-user gauges are only filled if per-user metrics are enabled.
+	// A username seen twice within a program is exported once.
+	reputation *metrics.GaugeSet
+	signal     *metrics.GaugeSet
+	impact     *metrics.GaugeSet
+}
+
+// Update implements Collector
+func (c *reportersCollector) Update(ctx context.Context, s *scrapeContext) (Result, error) {
+	programs, err := fetchPerProgram(ctx, s, func(ctx context.Context, p program) (*types.Reporters, error) {
+		return s.client.GetReporters(ctx, p.id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &reportersResult{
+		reporters:   metrics.NewGaugeSet(),
+		reputations: metrics.NewHistogramSet(metrics.ReputationBuckets),
+		reputation:  metrics.NewGaugeSet(),
+		signal:      metrics.NewGaugeSet(),
+		impact:      metrics.NewGaugeSet(),
+	}
+	for _, p := range programs {
+		for _, reporter := range p.data.Data {
+			attributes := reporter.Attributes
+			result.reporters.Add(1, p.program.handle)
+			result.reputations.Observe(float64(attributes.Reputation), p.program.handle)
+
+			if c.perUser {
+				result.reputation.Set(float64(attributes.Reputation), p.program.handle, attributes.Username)
+				result.signal.Set(attributes.Signal, p.program.handle, attributes.Username)
+				result.impact.Set(attributes.Impact, p.program.handle, attributes.Username)
+			}
+		}
+	}
+	return result, nil
+}
+
+// Collect implements Result
+func (r *reportersResult) Collect(m *metrics.Metrics, ch chan<- prometheus.Metric) {
+	r.reporters.Collect(ch, m.ReportersTotal)
+	r.reputations.Collect(ch, m.ReputationHistogram)
+	r.reputation.Collect(ch, m.ReporterReputation)
+	r.signal.Collect(ch, m.ReporterSignal)
+	r.impact.Collect(ch, m.ReporterImpact)
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/dirsigler/hackerone-exporter/pkg/config"
+	"github.com/dirsigler/hackerone-exporter/pkg/jsonapi"
+	"github.com/dirsigler/hackerone-exporter/pkg/metrics"
+	"github.com/dirsigler/hackerone-exporter/pkg/sla"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector("reports", defaultEnabled, newReportsCollector)
+}
+
+// reportsCollector counts the reports of every program by state, records
+// how long reports take to reach each stage of their lifecycle and evaluates
+// open reports against the configured SLA policy
+type reportsCollector struct {
+	labels []string
+	policy *sla.Policy
+}
+
+func newReportsCollector(cfg *config.Config) Collector {
+	return &reportsCollector{
+		labels: cfg.ReportLabels,
+		policy: sla.NewPolicy(cfg.SLATargets),
+	}
+}
+
+// supportedReportLabels are the labels hackerone_reports_total can be broken
+// down by in addition to organization_id
+var supportedReportLabels = []string{"program", "state", "severity_rating", "weakness_external_id", "asset_type"}
+
+// validateReportLabels checks that only supported labels are configured
+func validateReportLabels(labels []string) error {
+	for i, label := range labels {
+		if !slices.Contains(supportedReportLabels, label) {
+			return fmt.Errorf("unsupported report label %q, must be one of %v", label, supportedReportLabels)
+		}
+		if slices.Contains(labels[:i], label) {
+			return fmt.Errorf("duplicate report label %q", label)
+		}
+	}
+	return nil
+}
+
+type reportsResult struct {
+	reports         *metrics.GaugeSet
+	toFirstResponse *metrics.HistogramSet
+	toTriage        *metrics.HistogramSet
+	toResolution    *metrics.HistogramSet
+	toBounty        *metrics.HistogramSet
+	deadlines       slaDeadlines
+}
+
+// Update implements Collector
+func (c *reportsCollector) Update(ctx context.Context, s *scrapeContext) (Result, error) {
+	programs, err := fetchPerProgram(ctx, s, s.reports)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &reportsResult{
+		reports:         metrics.NewGaugeSet(),
+		toFirstResponse: metrics.NewHistogramSet(metrics.ReportLifecycleBuckets),
+		toTriage:        metrics.NewHistogramSet(metrics.ReportLifecycleBuckets),
+		toResolution:    metrics.NewHistogramSet(metrics.ReportLifecycleBuckets),
+		toBounty:        metrics.NewHistogramSet(metrics.ReportLifecycleBuckets),
+		deadlines:       make(slaDeadlines),
+	}
+
+	for _, p := range programs {
+		for _, report := range p.data.Data {
+			severity := labelValue(report.Relationships.Severity.Data.Attributes.Rating)
+
+			values := map[string]string{
+				"program":              p.program.handle,
+				"state":                report.Attributes.State,
+				"severity_rating":      severity,
+				"weakness_external_id": labelValue(report.Relationships.Weakness.Data.Attributes.ExternalID),
+				"asset_type":           labelValue(report.Relationships.StructuredScope.Data.Attributes.AssetType),
+			}
+			var countLabels []string
+			for _, label := range c.labels {
+				countLabels = append(countLabels, values[label])
+			}
+			result.reports.Add(1, countLabels...)
+
+			labels := []string{p.program.handle, severity}
+			created := report.Attributes.CreatedAt
+			observeSince(result.toFirstResponse, labels, created, report.Attributes.FirstProgramActivityAt)
+			observeSince(result.toTriage, labels, created, report.Attributes.TriagedAt)
+			observeSince(result.toResolution, labels, created, report.Attributes.ClosedAt)
+			observeSince(result.toBounty, labels, created, report.Attributes.BountyAwardedAt)
+
+			if !c.policy.Empty() {
+				result.deadlines.add(c.policy, p.program.handle, severity, report)
+			}
+		}
+	}
+	return result, nil
+}
+
+// Collect implements Result
+func (r *reportsResult) Collect(m *metrics.Metrics, ch chan<- prometheus.Metric) {
+	r.reports.Collect(ch, m.ReportsTotal)
+	r.toFirstResponse.Collect(ch, m.TimeToFirstResponse)
+	r.toTriage.Collect(ch, m.TimeToTriage)
+	r.toResolution.Collect(ch, m.TimeToResolution)
+	r.toBounty.Collect(ch, m.TimeToBounty)
+	r.deadlines.collect(m, ch, time.Now())
+}
+
+// labelValue returns value as a label value, substituting "unknown" for the
+// empty values of unrated reports and of reports without a weakness or asset
+func labelValue(value string) string {
+	if value == "" {
+		return "unknown"
+	}
+	return value
+}
+
+// observeSince records the time between start and end if the report has
+// reached the stage marked by end
+func observeSince(h *metrics.HistogramSet, labels []string, start time.Time, end jsonapi.NullTime) {
+	if !end.Valid || start.IsZero() || end.Time.Before(start) {
+		return
+	}
+	h.Observe(end.Time.Sub(start).Seconds(), labels...)
+}
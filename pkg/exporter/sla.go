@@ -0,0 +1,87 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"time"
+
+	"github.com/dirsigler/hackerone-exporter/pkg/jsonapi"
+	"github.com/dirsigler/hackerone-exporter/pkg/metrics"
+	"github.com/dirsigler/hackerone-exporter/pkg/sla"
+	"github.com/dirsigler/hackerone-exporter/pkg/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// slaBucket groups open reports that share an SLA target
+type slaBucket struct {
+	program  string
+	severity string
+	stage    sla.Stage
+}
+
+// slaDeadlines holds the deadlines of the open reports of every bucket. Only
+// the deadlines are kept so that the breached reports and the remaining time
+// can be evaluated against the time of each Prometheus scrape.
+type slaDeadlines map[slaBucket][]time.Time
+
+// add records a deadline for every stage the open report has not reached yet
+// and the policy has a target for
+func (d slaDeadlines) add(policy *sla.Policy, program, severity string, report types.Report) {
+	if report.Attributes.ClosedAt.Valid {
+		return
+	}
+
+	reached := map[sla.Stage]jsonapi.NullTime{
+		sla.StageFirstResponse: report.Attributes.FirstProgramActivityAt,
+		sla.StageTriage:        report.Attributes.TriagedAt,
+		sla.StageBounty:        report.Attributes.BountyAwardedAt,
+		sla.StageResolution:    report.Attributes.ClosedAt,
+	}
+
+	for _, stage := range sla.Stages {
+		if reached[stage].Valid {
+			continue
+		}
+
+		within, ok := policy.Within(program, severity, stage)
+		if !ok {
+			continue
+		}
+
+		bucket := slaBucket{program: program, severity: severity, stage: stage}
+		d[bucket] = append(d[bucket], report.Attributes.CreatedAt.Add(within))
+	}
+}
+
+// collect sends the number of breached reports and the time left for the
+// oldest open report of every bucket, negative once its deadline has passed
+func (d slaDeadlines) collect(m *metrics.Metrics, ch chan<- prometheus.Metric, now time.Time) {
+	for bucket, deadlines := range d {
+		breached := 0
+		oldest := deadlines[0]
+		for _, deadline := range deadlines {
+			if deadline.Before(now) {
+				breached++
+			}
+			if deadline.Before(oldest) {
+				oldest = deadline
+			}
+		}
+
+		labels := []string{bucket.program, bucket.severity, string(bucket.stage)}
+		ch <- prometheus.MustNewConstMetric(m.SLABreachedReports, prometheus.GaugeValue, float64(breached), labels...)
+		ch <- prometheus.MustNewConstMetric(m.SLARemaining, prometheus.GaugeValue, oldest.Sub(now).Seconds(), labels...)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"time"
+)
+
+// Status describes the outcome of the latest scrape of every organization
+type Status struct {
+	// Ready is true once every organization was scraped successfully within
+	// the configured maximum scrape age
+	Ready         bool                 `json:"ready"`
+	Collectors    []string             `json:"collectors"`
+	Organizations []OrganizationStatus `json:"organizations"`
+}
+
+// OrganizationStatus describes the latest scrape of a single organization
+type OrganizationStatus struct {
+	ID                   string            `json:"id"`
+	Up                   bool              `json:"up"`
+	Error                string            `json:"error,omitempty"`
+	LastScrape           *time.Time        `json:"last_scrape,omitempty"`
+	LastSuccessfulScrape *time.Time        `json:"last_successful_scrape,omitempty"`
+	APIRequests          float64           `json:"api_requests"`
+	APIErrors            float64           `json:"api_errors"`
+	APIThrottled         float64           `json:"api_throttled"`
+	Collectors           []CollectorStatus `json:"collectors"`
+}
+
+// CollectorStatus describes the latest update of a collector
+type CollectorStatus struct {
+	Name            string  `json:"name"`
+	Success         bool    `json:"success"`
+	DurationSeconds float64 `json:"duration_seconds"`
+	LastError       string  `json:"last_error,omitempty"`
+}
+
+// Status reports the state of the latest scrape. Organizations that were
+// never scraped, or not successfully within ReadyMaxScrapeAge, make the
+// exporter unready.
+func (e *Exporter) Status() Status {
+	st := e.state.Load()
+	snap := e.snapshot.Load()
+
+	usage := e.instrumentation.APIUsage()
+
+	status := Status{
+		Ready:         true,
+		Collectors:    e.Collectors(),
+		Organizations: []OrganizationStatus{},
+	}
+	for _, t := range st.targets {
+		orgStatus := OrganizationStatus{
+			ID:           t.orgID,
+			APIRequests:  usage[t.orgID].Requests,
+			APIErrors:    usage[t.orgID].Errors,
+			APIThrottled: usage[t.orgID].Throttled,
+			Collectors:   []CollectorStatus{},
+		}
+
+		var org *orgSnapshot
+		if snap != nil {
+			org = snap.orgs[t.orgID]
+		}
+		if org == nil {
+			status.Ready = false
+			status.Organizations = append(status.Organizations, orgStatus)
+			continue
+		}
+
+		orgStatus.Up = org.up
+		orgStatus.LastScrape = &org.finishedAt
+		if org.err != nil {
+			orgStatus.Error = org.err.Error()
+		}
+		if !org.lastSuccess.IsZero() {
+			orgStatus.LastSuccessfulScrape = &org.lastSuccess
+		}
+		if org.lastSuccess.IsZero() || time.Since(org.lastSuccess) > st.config.ReadyMaxScrapeAge {
+			status.Ready = false
+		}
+
+		for _, name := range collectorNames() {
+			collector, ok := org.statuses[name]
+			if !ok {
+				continue
+			}
+
+			collectorStatus := CollectorStatus{
+				Name:            name,
+				Success:         collector.success,
+				DurationSeconds: collector.duration.Seconds(),
+			}
+			if collector.lastErr != nil {
+				collectorStatus.LastError = collector.lastErr.Error()
+			}
+			orgStatus.Collectors = append(orgStatus.Collectors, collectorStatus)
+		}
+
+		status.Organizations = append(status.Organizations, orgStatus)
+	}
+	return status
+}
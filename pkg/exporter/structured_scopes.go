@@ -0,0 +1,63 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"context"
+
+	"github.com/dirsigler/hackerone-exporter/pkg/config"
+	"github.com/dirsigler/hackerone-exporter/pkg/metrics"
+	"github.com/dirsigler/hackerone-exporter/pkg/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector("structured_scopes", defaultEnabled, newStructuredScopesCollector)
+}
+
+// structuredScopesCollector exposes the structured scopes of every program
+type structuredScopesCollector struct{}
+
+func newStructuredScopesCollector(_ *config.Config) Collector {
+	return &structuredScopesCollector{}
+}
+
+type structuredScopesResult struct {
+	scopes *metrics.GaugeSet
+}
+
+// Update implements Collector
+func (c *structuredScopesCollector) Update(ctx context.Context, s *scrapeContext) (Result, error) {
+	programs, err := fetchPerProgram(ctx, s, func(ctx context.Context, p program) (*types.StructuredScopes, error) {
+		return s.client.GetStructuredScopes(ctx, p.id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := metrics.NewGaugeSet()
+	for _, p := range programs {
+		for _, scope := range p.data.Data {
+			scopes.Add(1, scope.Attributes.AssetIdentifier, scope.Attributes.AssetType)
+		}
+	}
+
+	return &structuredScopesResult{scopes: scopes}, nil
+}
+
+// Collect implements Result
+func (r *structuredScopesResult) Collect(m *metrics.Metrics, ch chan<- prometheus.Metric) {
+	r.scopes.Collect(ch, m.StructuredScopesTotal)
+}
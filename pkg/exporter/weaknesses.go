@@ -0,0 +1,63 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"context"
+
+	"github.com/dirsigler/hackerone-exporter/pkg/config"
+	"github.com/dirsigler/hackerone-exporter/pkg/metrics"
+	"github.com/dirsigler/hackerone-exporter/pkg/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	registerCollector("weaknesses", defaultEnabled, newWeaknessesCollector)
+}
+
+// weaknessesCollector exposes the weaknesses configured for every program
+type weaknessesCollector struct{}
+
+func newWeaknessesCollector(_ *config.Config) Collector {
+	return &weaknessesCollector{}
+}
+
+type weaknessesResult struct {
+	weaknesses *metrics.GaugeSet
+}
+
+// Update implements Collector
+func (c *weaknessesCollector) Update(ctx context.Context, s *scrapeContext) (Result, error) {
+	programs, err := fetchPerProgram(ctx, s, func(ctx context.Context, p program) (*types.Weaknesses, error) {
+		return s.client.GetWeaknesses(ctx, p.id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	weaknesses := metrics.NewGaugeSet()
+	for _, p := range programs {
+		for _, weakness := range p.data.Data {
+			weaknesses.Add(1, weakness.Attributes.Name, weakness.ID)
+		}
+	}
+
+	return &weaknessesResult{weaknesses: weaknesses}, nil
+}
+
+// Collect implements Result
+func (r *weaknessesResult) Collect(m *metrics.Metrics, ch chan<- prometheus.Metric) {
+	r.weaknesses.Collect(ch, m.WeaknessesTotal)
+}
@@ -0,0 +1,209 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsonapi decodes JSON:API documents (https://jsonapi.org) into
+// typed resources. Relationships are resolved against the `included` array
+// of the document, so related resources carry their attributes no matter
+// whether the API embedded them or only referenced them.
+package jsonapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+// Document is a JSON:API top-level document. T is a Resource for single
+// resources and a slice of Resources for collections.
+type Document[T any] struct {
+	Data  T     `json:"data"`
+	Links Links `json:"links"`
+}
+
+// Links holds the pagination links returned with every collection
+type Links struct {
+	Self  string `json:"self"`
+	First string `json:"first"`
+	Prev  string `json:"prev"`
+	Next  string `json:"next"`
+	Last  string `json:"last"`
+}
+
+// Resource is a JSON:API resource object with attributes A and
+// relationships R. R is a struct of ToOne and ToMany fields, or None.
+type Resource[A, R any] struct {
+	ID            string `json:"id"`
+	Type          string `json:"type"`
+	Attributes    A      `json:"attributes"`
+	Relationships R      `json:"relationships"`
+}
+
+// None is the relationships of resources without relationships
+type None struct{}
+
+// ToOne is a to-one relationship. Data is the zero value if the
+// relationship is empty.
+type ToOne[T any] struct {
+	Data T `json:"data"`
+}
+
+// ToMany is a to-many relationship
+type ToMany[T any] struct {
+	Data []T `json:"data"`
+}
+
+// NullTime is a timestamp that may be null
+type NullTime struct {
+	Time  time.Time
+	Valid bool
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *NullTime) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*t = NullTime{}
+		return nil
+	}
+
+	if err := json.Unmarshal(data, &t.Time); err != nil {
+		return err
+	}
+	t.Valid = true
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (t NullTime) MarshalJSON() ([]byte, error) {
+	if !t.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.Time)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Resource linkage found in the
+// relationships of the primary data is replaced by the matching resource of
+// the `included` array before the data is decoded into T.
+func (d *Document[T]) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Data     json.RawMessage   `json:"data"`
+		Included []json.RawMessage `json:"included"`
+		Links    Links             `json:"links"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	d.Links = raw.Links
+
+	data := raw.Data
+	if len(raw.Included) > 0 {
+		resolved, err := resolveIncluded(raw.Data, raw.Included)
+		if err != nil {
+			return err
+		}
+		data = resolved
+	}
+
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, &d.Data)
+}
+
+// identifier identifies a resource within a document
+type identifier struct {
+	id, typ string
+}
+
+// resolveIncluded embeds the included resources into the relationships of
+// data. Resources referencing each other are only embedded once per path, so
+// cycles end with a plain resource linkage.
+func resolveIncluded(data json.RawMessage, included []json.RawMessage) (json.RawMessage, error) {
+	index := make(map[identifier]map[string]any, len(included))
+	for _, raw := range included {
+		var resource map[string]any
+		if err := decode(raw, &resource); err != nil {
+			return nil, err
+		}
+		if id, ok := identify(resource); ok {
+			index[id] = resource
+		}
+	}
+
+	var tree any
+	if err := decode(data, &tree); err != nil {
+		return nil, err
+	}
+
+	r := &resolver{index: index, visiting: make(map[identifier]bool)}
+	return json.Marshal(r.resolve(tree))
+}
+
+type resolver struct {
+	index    map[identifier]map[string]any
+	visiting map[identifier]bool
+}
+
+// resolve returns a copy of node with every resolvable linkage replaced
+func (r *resolver) resolve(node any) any {
+	switch v := node.(type) {
+	case map[string]any:
+		id, ok := identify(v)
+		if ok {
+			if _, hasAttributes := v["attributes"]; !hasAttributes {
+				if resource, found := r.index[id]; found && !r.visiting[id] {
+					v = resource
+				}
+			}
+			if r.visiting[id] {
+				return v
+			}
+			r.visiting[id] = true
+			defer delete(r.visiting, id)
+		}
+
+		resolved := make(map[string]any, len(v))
+		for key, child := range v {
+			resolved[key] = r.resolve(child)
+		}
+		return resolved
+	case []any:
+		resolved := make([]any, len(v))
+		for i, child := range v {
+			resolved[i] = r.resolve(child)
+		}
+		return resolved
+	default:
+		return v
+	}
+}
+
+// identify returns the identifier of a resource object or resource linkage
+func identify(v map[string]any) (identifier, bool) {
+	id, ok := v["id"].(string)
+	if !ok {
+		return identifier{}, false
+	}
+	typ, ok := v["type"].(string)
+	if !ok {
+		return identifier{}, false
+	}
+	return identifier{id: id, typ: typ}, true
+}
+
+// decode unmarshals JSON without losing the precision of numbers
+func decode(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
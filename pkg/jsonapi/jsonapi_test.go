@@ -0,0 +1,213 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonapi_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dirsigler/hackerone-exporter/pkg/jsonapi"
+)
+
+type reportAttributes struct {
+	Title    string           `json:"title"`
+	ClosedAt jsonapi.NullTime `json:"closed_at"`
+}
+
+type reportRelationships struct {
+	Reporter jsonapi.ToOne[user]    `json:"reporter"`
+	Bounties jsonapi.ToMany[bounty] `json:"bounties"`
+}
+
+type report = jsonapi.Resource[reportAttributes, reportRelationships]
+
+type userAttributes struct {
+	Username string `json:"username"`
+}
+
+type userRelationships struct {
+	Reports jsonapi.ToMany[report] `json:"reports"`
+}
+
+type user = jsonapi.Resource[userAttributes, userRelationships]
+
+type bountyAttributes struct {
+	Amount string `json:"amount"`
+}
+
+type bounty = jsonapi.Resource[bountyAttributes, jsonapi.None]
+
+func TestDocumentUnmarshalJSON(t *testing.T) {
+	closedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		document string
+		want     []report
+	}{
+		{
+			name: "to-one and to-many linkage resolved from included",
+			document: `{
+				"data": [{"id": "1", "type": "report", "attributes": {"title": "XSS"}, "relationships": {
+					"reporter": {"data": {"id": "7", "type": "user"}},
+					"bounties": {"data": [{"id": "3", "type": "bounty"}, {"id": "4", "type": "bounty"}]}
+				}}],
+				"included": [
+					{"id": "7", "type": "user", "attributes": {"username": "alice"}},
+					{"id": "3", "type": "bounty", "attributes": {"amount": "500.00"}},
+					{"id": "4", "type": "bounty", "attributes": {"amount": "50.00"}}
+				]
+			}`,
+			want: []report{{
+				ID: "1", Type: "report",
+				Attributes: reportAttributes{Title: "XSS"},
+				Relationships: reportRelationships{
+					Reporter: jsonapi.ToOne[user]{Data: user{ID: "7", Type: "user", Attributes: userAttributes{Username: "alice"}}},
+					Bounties: jsonapi.ToMany[bounty]{Data: []bounty{
+						{ID: "3", Type: "bounty", Attributes: bountyAttributes{Amount: "500.00"}},
+						{ID: "4", Type: "bounty", Attributes: bountyAttributes{Amount: "50.00"}},
+					}},
+				},
+			}},
+		},
+		{
+			name: "embedded resources are kept",
+			document: `{
+				"data": [{"id": "1", "type": "report", "relationships": {
+					"reporter": {"data": {"id": "7", "type": "user", "attributes": {"username": "alice"}}}
+				}}]
+			}`,
+			want: []report{{
+				ID: "1", Type: "report",
+				Relationships: reportRelationships{
+					Reporter: jsonapi.ToOne[user]{Data: user{ID: "7", Type: "user", Attributes: userAttributes{Username: "alice"}}},
+				},
+			}},
+		},
+		{
+			name: "linkage missing from included",
+			document: `{
+				"data": [{"id": "1", "type": "report", "relationships": {
+					"reporter": {"data": {"id": "9", "type": "user"}}
+				}}],
+				"included": [{"id": "7", "type": "user", "attributes": {"username": "alice"}}]
+			}`,
+			want: []report{{
+				ID: "1", Type: "report",
+				Relationships: reportRelationships{
+					Reporter: jsonapi.ToOne[user]{Data: user{ID: "9", Type: "user"}},
+				},
+			}},
+		},
+		{
+			name: "cycle between included resources",
+			document: `{
+				"data": [{"id": "1", "type": "report", "relationships": {
+					"reporter": {"data": {"id": "7", "type": "user"}}
+				}}],
+				"included": [
+					{"id": "7", "type": "user", "attributes": {"username": "alice"}, "relationships": {
+						"reports": {"data": [{"id": "2", "type": "report"}]}
+					}},
+					{"id": "2", "type": "report", "attributes": {"title": "SSRF"}, "relationships": {
+						"reporter": {"data": {"id": "7", "type": "user"}}
+					}}
+				]
+			}`,
+			want: []report{{
+				ID: "1", Type: "report",
+				Relationships: reportRelationships{
+					Reporter: jsonapi.ToOne[user]{Data: user{
+						ID: "7", Type: "user",
+						Attributes: userAttributes{Username: "alice"},
+						Relationships: userRelationships{Reports: jsonapi.ToMany[report]{Data: []report{{
+							ID: "2", Type: "report",
+							Attributes: reportAttributes{Title: "SSRF"},
+							Relationships: reportRelationships{
+								// The cycle ends with the plain linkage
+								Reporter: jsonapi.ToOne[user]{Data: user{ID: "7", Type: "user"}},
+							},
+						}}}},
+					}},
+				},
+			}},
+		},
+		{
+			name: "null to-one",
+			document: `{
+				"data": [{"id": "1", "type": "report", "relationships": {"reporter": {"data": null}}}],
+				"included": [{"id": "7", "type": "user", "attributes": {"username": "alice"}}]
+			}`,
+			want: []report{{ID: "1", Type: "report"}},
+		},
+		{
+			name: "null and set timestamps",
+			document: `{
+				"data": [
+					{"id": "1", "type": "report", "attributes": {"closed_at": null}},
+					{"id": "2", "type": "report", "attributes": {"closed_at": "2025-06-01T12:00:00Z"}}
+				]
+			}`,
+			want: []report{
+				{ID: "1", Type: "report"},
+				{ID: "2", Type: "report", Attributes: reportAttributes{ClosedAt: jsonapi.NullTime{Time: closedAt, Valid: true}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var document jsonapi.Document[[]report]
+			if err := json.Unmarshal([]byte(tt.document), &document); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if !reflect.DeepEqual(document.Data, tt.want) {
+				t.Errorf("Data = %+v\nwant %+v", document.Data, tt.want)
+			}
+		})
+	}
+}
+
+func TestNullTime(t *testing.T) {
+	tests := []struct {
+		json string
+		want jsonapi.NullTime
+	}{
+		{`null`, jsonapi.NullTime{}},
+		{`"2025-06-01T12:00:00Z"`, jsonapi.NullTime{Time: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC), Valid: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.json, func(t *testing.T) {
+			var got jsonapi.NullTime
+			if err := json.Unmarshal([]byte(tt.json), &got); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("json.Unmarshal() = %+v, want %+v", got, tt.want)
+			}
+
+			encoded, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if string(encoded) != tt.json {
+				t.Errorf("json.Marshal() = %s, want %s", encoded, tt.json)
+			}
+		})
+	}
+}
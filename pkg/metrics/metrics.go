@@ -0,0 +1,382 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+const (
+	namespace = "hackerone"
+)
+
+// Metrics holds the Prometheus metrics of a single HackerOne organization.
+// Descriptors are used to build const metrics from each scrape snapshot,
+// while the remaining fields instrument the exporter itself and are backed by
+// the shared Instrumentation. Every metric is labelled with organization_id.
+type Metrics struct {
+	AssetsTotal           *prometheus.Desc
+	ReportsTotal          *prometheus.Desc
+	TimeToFirstResponse   *prometheus.Desc
+	TimeToTriage          *prometheus.Desc
+	TimeToResolution      *prometheus.Desc
+	TimeToBounty          *prometheus.Desc
+	SLABreachedReports    *prometheus.Desc
+	SLARemaining          *prometheus.Desc
+	ProgramsTotal         *prometheus.Desc
+	BountiesAwardedAmount *prometheus.Desc
+	BountiesAwardedCount  *prometheus.Desc
+	ProgramBalance        *prometheus.Desc
+	InvitedHackersTotal   *prometheus.Desc
+	WeaknessesTotal       *prometheus.Desc
+	StructuredScopesTotal *prometheus.Desc
+	ReportersTotal        *prometheus.Desc
+	ReporterReputation    *prometheus.Desc
+	ReporterSignal        *prometheus.Desc
+	ReporterImpact        *prometheus.Desc
+	ReputationHistogram   *prometheus.Desc
+	LastScrapeTime        *prometheus.Desc
+	CollectorSuccess      *prometheus.Desc
+	CollectorDuration     *prometheus.Desc
+	DataAge               *prometheus.Desc
+	Up                    *prometheus.Desc
+	ScrapeDuration        prometheus.Observer
+	ScrapeErrors          prometheus.Counter
+	APIRequests           *prometheus.CounterVec
+	APIRequestDuration    prometheus.ObserverVec
+	APIThrottled          prometheus.Counter
+	APIErrors             *prometheus.CounterVec
+}
+
+// Instrumentation holds the metrics instrumenting the exporter itself. It
+// outlives configuration reloads so that its counters keep accumulating.
+type Instrumentation struct {
+	ScrapeDuration     *prometheus.HistogramVec
+	ScrapeErrors       *prometheus.CounterVec
+	APIRequests        *prometheus.CounterVec
+	APIRequestDuration *prometheus.HistogramVec
+	APIThrottled       *prometheus.CounterVec
+	APIErrors          *prometheus.CounterVec
+}
+
+// organizationLabel is the label every metric family carries
+const organizationLabel = "organization_id"
+
+// reportLifecycleLabels are the labels of the report lifecycle histograms
+var reportLifecycleLabels = []string{"program", "severity"}
+
+// slaLabels are the labels of the SLA metrics
+var slaLabels = []string{"program", "severity", "stage"}
+
+// ReputationBuckets spans new reporters to the top of the HackerOne leaderboard
+var ReputationBuckets = []float64{0, 10, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+
+// ReportLifecycleBuckets spans one hour to half a year in seconds
+var ReportLifecycleBuckets = []float64{
+	(1 * time.Hour).Seconds(),
+	(4 * time.Hour).Seconds(),
+	(12 * time.Hour).Seconds(),
+	(24 * time.Hour).Seconds(),
+	(2 * 24 * time.Hour).Seconds(),
+	(3 * 24 * time.Hour).Seconds(),
+	(7 * 24 * time.Hour).Seconds(),
+	(14 * 24 * time.Hour).Seconds(),
+	(30 * 24 * time.Hour).Seconds(),
+	(60 * 24 * time.Hour).Seconds(),
+	(90 * 24 * time.Hour).Seconds(),
+	(180 * 24 * time.Hour).Seconds(),
+}
+
+// newDesc creates a descriptor for a metric of an organization in the
+// hackerone namespace
+func newDesc(orgID, name, help string, labels []string) *prometheus.Desc {
+	return prometheus.NewDesc(prometheus.BuildFQName(namespace, "", name), help, labels, prometheus.Labels{organizationLabel: orgID})
+}
+
+// New creates the Prometheus metrics of the organization orgID. reportLabels
+// are the labels of hackerone_reports_total in addition to organization_id.
+func New(orgID string, reportLabels []string, instrumentation *Instrumentation) *Metrics {
+	m := &Metrics{
+		ScrapeDuration:     instrumentation.ScrapeDuration.WithLabelValues(orgID),
+		ScrapeErrors:       instrumentation.ScrapeErrors.WithLabelValues(orgID),
+		APIRequests:        instrumentation.APIRequests.MustCurryWith(prometheus.Labels{organizationLabel: orgID}),
+		APIRequestDuration: instrumentation.APIRequestDuration.MustCurryWith(prometheus.Labels{organizationLabel: orgID}),
+		APIThrottled:       instrumentation.APIThrottled.WithLabelValues(orgID),
+		APIErrors:          instrumentation.APIErrors.MustCurryWith(prometheus.Labels{organizationLabel: orgID}),
+
+		AssetsTotal: newDesc(orgID, "assets_total",
+			"Total number of HackerOne Assets",
+			nil,
+		),
+		ReportsTotal: newDesc(orgID, "reports_total",
+			"Total number of HackerOne Reports",
+			reportLabels,
+		),
+		TimeToFirstResponse: newDesc(orgID, "report_time_to_first_response_seconds",
+			"Time from report creation to the first program activity in seconds",
+			reportLifecycleLabels,
+		),
+		TimeToTriage: newDesc(orgID, "report_time_to_triage_seconds",
+			"Time from report creation to triage in seconds",
+			reportLifecycleLabels,
+		),
+		TimeToResolution: newDesc(orgID, "report_time_to_resolution_seconds",
+			"Time from report creation to closing in seconds",
+			reportLifecycleLabels,
+		),
+		TimeToBounty: newDesc(orgID, "report_time_to_bounty_seconds",
+			"Time from report creation to the first bounty award in seconds",
+			reportLifecycleLabels,
+		),
+		SLABreachedReports: newDesc(orgID, "sla_breached_reports",
+			"Number of open reports that missed their SLA target for a stage",
+			slaLabels,
+		),
+		SLARemaining: newDesc(orgID, "sla_remaining_seconds",
+			"Time left until the oldest open report misses its SLA target for a stage, negative once breached",
+			slaLabels,
+		),
+		ProgramsTotal: newDesc(orgID, "programs_total",
+			"Total number of HackerOne Programs",
+			[]string{"handle"},
+		),
+		BountiesAwardedAmount: newDesc(orgID, "bounties_awarded_amount_total",
+			"Total amount of bounties and bonuses awarded on reports",
+			[]string{"program", "severity", "currency"},
+		),
+		BountiesAwardedCount: newDesc(orgID, "bounties_awarded_count",
+			"Number of bounties awarded on reports",
+			[]string{"program", "severity"},
+		),
+		ProgramBalance: newDesc(orgID, "program_balance",
+			"Current billing balance of a HackerOne Program",
+			[]string{"program"},
+		),
+		InvitedHackersTotal: newDesc(orgID, "invited_hackers_total",
+			"Total number of HackerOne Invited Hackers",
+			[]string{"state"},
+		),
+		WeaknessesTotal: newDesc(orgID, "weaknesses_total",
+			"Total number of HackerOne Weaknesses",
+			[]string{"name", "id"},
+		),
+		StructuredScopesTotal: newDesc(orgID, "structured_scopes_total",
+			"Total number of HackerOne Structured Scopes",
+			[]string{"asset_identifier", "asset_type"},
+		),
+		ReportersTotal: newDesc(orgID, "reporters_total",
+			"Total number of HackerOne Reporters",
+			[]string{"program"},
+		),
+		ReporterReputation: newDesc(orgID, "reporter_reputation",
+			"Reputation of a HackerOne Reporter",
+			[]string{"program", "username"},
+		),
+		ReporterSignal: newDesc(orgID, "reporter_signal",
+			"Signal of a HackerOne Reporter",
+			[]string{"program", "username"},
+		),
+		ReporterImpact: newDesc(orgID, "reporter_impact",
+			"Impact of a HackerOne Reporter",
+			[]string{"program", "username"},
+		),
+		ReputationHistogram: newDesc(orgID, "reporter_reputation_distribution",
+			"Distribution of the reputation of HackerOne Reporters",
+			[]string{"program"},
+		),
+		LastScrapeTime: newDesc(orgID, "last_scrape_timestamp",
+			"Unix timestamp of the last successful scrape",
+			nil,
+		),
+		CollectorSuccess: newDesc(orgID, "scrape_collector_success",
+			"Whether a collector succeeded during the last scrape",
+			[]string{"collector"},
+		),
+		CollectorDuration: newDesc(orgID, "scrape_collector_duration_seconds",
+			"Duration of a collector during the last scrape in seconds",
+			[]string{"collector"},
+		),
+		DataAge: newDesc(orgID, "data_age_seconds",
+			"Seconds since the data exposed by a collector was last fetched successfully",
+			[]string{"collector"},
+		),
+		Up: newDesc(orgID, "up",
+			"Whether the HackerOne API could be reached during the last scrape",
+			nil,
+		),
+	}
+
+	return m
+}
+
+// NewInstrumentation creates the metrics instrumenting the exporter itself
+func NewInstrumentation() *Instrumentation {
+	return &Instrumentation{
+		ScrapeErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:      "scrape_errors_total",
+			Help:      "Total number of HackerOne API scrape errors",
+			Namespace: namespace,
+		},
+			[]string{organizationLabel},
+		),
+		ScrapeDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:      "scrape_duration_seconds",
+			Help:      "Duration of HackerOne API scrapes in seconds",
+			Namespace: namespace,
+			Buckets:   prometheus.DefBuckets,
+		},
+			[]string{organizationLabel},
+		),
+		APIRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:      "api_requests_total",
+			Help:      "Total number of HTTP requests sent to the HackerOne API",
+			Namespace: namespace,
+		},
+			[]string{organizationLabel, "endpoint", "code"},
+		),
+		APIRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:      "api_request_duration_seconds",
+			Help:      "Duration of HTTP requests to the HackerOne API in seconds",
+			Namespace: namespace,
+			Buckets:   prometheus.DefBuckets,
+		},
+			[]string{organizationLabel, "endpoint"},
+		),
+		APIThrottled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:      "api_throttled_total",
+			Help:      "Total number of HackerOne API requests rejected with 429 Too Many Requests",
+			Namespace: namespace,
+		},
+			[]string{organizationLabel},
+		),
+		APIErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:      "api_errors_total",
+			Help:      "Total number of failed HackerOne API requests after retries by error class (auth, forbidden, not_found, rate_limit, server, client, timeout, network)",
+			Namespace: namespace,
+		},
+			[]string{organizationLabel, "endpoint", "class"},
+		),
+	}
+}
+
+// Describe sends the descriptors of the organization's metrics to ch
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{
+		m.AssetsTotal,
+		m.ReportsTotal,
+		m.TimeToFirstResponse,
+		m.TimeToTriage,
+		m.TimeToResolution,
+		m.TimeToBounty,
+		m.SLABreachedReports,
+		m.SLARemaining,
+		m.ProgramsTotal,
+		m.BountiesAwardedAmount,
+		m.BountiesAwardedCount,
+		m.ProgramBalance,
+		m.InvitedHackersTotal,
+		m.WeaknessesTotal,
+		m.StructuredScopesTotal,
+		m.ReportersTotal,
+		m.ReporterReputation,
+		m.ReporterSignal,
+		m.ReporterImpact,
+		m.ReputationHistogram,
+		m.LastScrapeTime,
+		m.CollectorSuccess,
+		m.CollectorDuration,
+		m.DataAge,
+		m.Up,
+	} {
+		ch <- desc
+	}
+}
+
+// Describe sends the descriptors of the instrumentation metrics to ch
+func (i *Instrumentation) Describe(ch chan<- *prometheus.Desc) {
+	i.ScrapeErrors.Describe(ch)
+	i.ScrapeDuration.Describe(ch)
+	i.APIRequests.Describe(ch)
+	i.APIRequestDuration.Describe(ch)
+	i.APIThrottled.Describe(ch)
+	i.APIErrors.Describe(ch)
+}
+
+// Collect sends the instrumentation metrics to ch
+func (i *Instrumentation) Collect(ch chan<- prometheus.Metric) {
+	i.ScrapeErrors.Collect(ch)
+	i.ScrapeDuration.Collect(ch)
+	i.APIRequests.Collect(ch)
+	i.APIRequestDuration.Collect(ch)
+	i.APIThrottled.Collect(ch)
+	i.APIErrors.Collect(ch)
+}
+
+// APIUsage summarizes the HackerOne API requests of an organization
+type APIUsage struct {
+	Requests  float64
+	Errors    float64
+	Throttled float64
+}
+
+// APIUsage sums the API requests of every organization. Requests that failed
+// or did not return 2xx count as errors.
+func (i *Instrumentation) APIUsage() map[string]APIUsage {
+	usage := make(map[string]APIUsage)
+
+	forEachCounter(i.APIRequests, func(labels map[string]string, value float64) {
+		u := usage[labels[organizationLabel]]
+		u.Requests += value
+		if !strings.HasPrefix(labels["code"], "2") {
+			u.Errors += value
+		}
+		usage[labels[organizationLabel]] = u
+	})
+
+	forEachCounter(i.APIThrottled, func(labels map[string]string, value float64) {
+		u := usage[labels[organizationLabel]]
+		u.Throttled += value
+		usage[labels[organizationLabel]] = u
+	})
+
+	return usage
+}
+
+// forEachCounter calls fn with the labels and value of every counter
+// collected from c
+func forEachCounter(c prometheus.Collector, fn func(labels map[string]string, value float64)) {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		c.Collect(ch)
+		close(ch)
+	}()
+
+	for metric := range ch {
+		var m dto.Metric
+		if err := metric.Write(&m); err != nil || m.Counter == nil {
+			continue
+		}
+
+		labels := make(map[string]string, len(m.GetLabel()))
+		for _, label := range m.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+		fn(labels, m.Counter.GetValue())
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// labelKey joins label values into a map key
+func labelKey(labelValues []string) string {
+	return strings.Join(labelValues, "\xff")
+}
+
+// GaugeSet sums values per label set and emits them as const gauges. Sets are
+// filled when the API is scraped and emitted on every Prometheus scrape.
+type GaugeSet struct {
+	keys   []string
+	labels map[string][]string
+	values map[string]float64
+}
+
+// NewGaugeSet creates an empty GaugeSet
+func NewGaugeSet() *GaugeSet {
+	return &GaugeSet{
+		labels: make(map[string][]string),
+		values: make(map[string]float64),
+	}
+}
+
+// Add adds value to the gauge with the given label values
+func (g *GaugeSet) Add(value float64, labelValues ...string) {
+	key := labelKey(labelValues)
+	if _, ok := g.labels[key]; !ok {
+		g.keys = append(g.keys, key)
+		g.labels[key] = labelValues
+	}
+	g.values[key] += value
+}
+
+// Set replaces the value of the gauge with the given label values
+func (g *GaugeSet) Set(value float64, labelValues ...string) {
+	g.Add(0, labelValues...)
+	g.values[labelKey(labelValues)] = value
+}
+
+// Collect sends every gauge of the set to ch as a metric of desc
+func (g *GaugeSet) Collect(ch chan<- prometheus.Metric, desc *prometheus.Desc) {
+	for _, key := range g.keys {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, g.values[key], g.labels[key]...)
+	}
+}
+
+// HistogramSet records observations per label set and emits them as const
+// histograms
+type HistogramSet struct {
+	buckets []float64
+	keys    []string
+	series  map[string]*histogram
+}
+
+type histogram struct {
+	labelValues []string
+	count       uint64
+	sum         float64
+	buckets     map[float64]uint64
+}
+
+// NewHistogramSet creates an empty HistogramSet with the given upper bucket
+// bounds
+func NewHistogramSet(buckets []float64) *HistogramSet {
+	buckets = append([]float64{}, buckets...)
+	sort.Float64s(buckets)
+
+	return &HistogramSet{
+		buckets: buckets,
+		series:  make(map[string]*histogram),
+	}
+}
+
+// Observe records value in the histogram with the given label values
+func (h *HistogramSet) Observe(value float64, labelValues ...string) {
+	key := labelKey(labelValues)
+	s, ok := h.series[key]
+	if !ok {
+		s = &histogram{labelValues: labelValues, buckets: make(map[float64]uint64, len(h.buckets))}
+		h.series[key] = s
+		h.keys = append(h.keys, key)
+	}
+
+	s.count++
+	s.sum += value
+	for _, upper := range h.buckets {
+		if value <= upper {
+			s.buckets[upper]++
+		}
+	}
+}
+
+// Collect sends every histogram of the set to ch as a metric of desc
+func (h *HistogramSet) Collect(ch chan<- prometheus.Metric, desc *prometheus.Desc) {
+	for _, key := range h.keys {
+		s := h.series[key]
+		ch <- prometheus.MustNewConstHistogram(desc, s.count, s.sum, s.buckets, s.labelValues...)
+	}
+}
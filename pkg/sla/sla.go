@@ -0,0 +1,131 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sla
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+// Stage is a step in the lifecycle of a report that an SLA target applies to
+type Stage string
+
+const (
+	StageFirstResponse Stage = "first_response"
+	StageTriage        Stage = "triage"
+	StageBounty        Stage = "bounty"
+	StageResolution    Stage = "resolution"
+)
+
+// Stages lists all stages in lifecycle order
+var Stages = []Stage{StageFirstResponse, StageTriage, StageBounty, StageResolution}
+
+// Target is the time within which reports of a severity must reach a stage.
+// An empty Program applies the target to every program.
+type Target struct {
+	Program  string
+	Severity string
+	Stage    Stage
+	Within   time.Duration
+}
+
+// ParseTarget parses a target given as [<program>:]<severity>:<stage>:<within>,
+// e.g. "critical:triage:24h" or "acme:high:resolution:30d". Durations accept
+// the Prometheus format, so "2d" or "1w" are valid as well.
+func ParseTarget(s string) (Target, error) {
+	fields := strings.Split(s, ":")
+	if len(fields) == 3 {
+		fields = append([]string{""}, fields...)
+	}
+	if len(fields) != 4 {
+		return Target{}, fmt.Errorf("invalid SLA target %q: expected [<program>:]<severity>:<stage>:<within>", s)
+	}
+
+	within, err := model.ParseDuration(fields[3])
+	if err != nil {
+		return Target{}, fmt.Errorf("invalid SLA target %q: %w", s, err)
+	}
+
+	t := Target{
+		Program:  fields[0],
+		Severity: fields[1],
+		Stage:    Stage(fields[2]),
+		Within:   time.Duration(within),
+	}
+	if err := t.Validate(); err != nil {
+		return Target{}, fmt.Errorf("invalid SLA target %q: %w", s, err)
+	}
+	return t, nil
+}
+
+// Validate checks that the target is complete
+func (t Target) Validate() error {
+	if t.Severity == "" {
+		return fmt.Errorf("severity is required")
+	}
+	if !slices.Contains(Stages, t.Stage) {
+		return fmt.Errorf("stage must be one of %v, got %q", Stages, t.Stage)
+	}
+	if t.Within <= 0 {
+		return fmt.Errorf("within must be positive")
+	}
+	return nil
+}
+
+// Policy is a set of SLA targets
+type Policy struct {
+	targets []Target
+}
+
+// NewPolicy creates a policy from the given targets
+func NewPolicy(targets []Target) *Policy {
+	return &Policy{targets: targets}
+}
+
+// Empty reports whether the policy has no targets
+func (p *Policy) Empty() bool {
+	return p == nil || len(p.targets) == 0
+}
+
+// Within returns the time allowed for reports of a program and severity to
+// reach a stage. Targets for a specific program take precedence over targets
+// that apply to every program.
+func (p *Policy) Within(program, severity string, stage Stage) (time.Duration, bool) {
+	if p == nil {
+		return 0, false
+	}
+
+	var (
+		within time.Duration
+		found  bool
+	)
+	for _, t := range p.targets {
+		if t.Severity != severity || t.Stage != stage {
+			continue
+		}
+
+		switch t.Program {
+		case program:
+			return t.Within, true
+		case "":
+			within, found = t.Within, true
+		}
+	}
+	return within, found
+}
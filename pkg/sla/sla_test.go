@@ -0,0 +1,121 @@
+// Copyright 2025 Dennis Irsigler
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sla_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dirsigler/hackerone-exporter/pkg/sla"
+)
+
+func TestParseTarget(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    sla.Target
+		wantErr bool
+	}{
+		{value: "critical:triage:24h", want: sla.Target{Severity: "critical", Stage: sla.StageTriage, Within: 24 * time.Hour}},
+		{value: "acme:high:resolution:30d", want: sla.Target{Program: "acme", Severity: "high", Stage: sla.StageResolution, Within: 30 * 24 * time.Hour}},
+		{value: "medium:first_response:1w", want: sla.Target{Severity: "medium", Stage: sla.StageFirstResponse, Within: 7 * 24 * time.Hour}},
+		{value: "low:bounty:1d12h", want: sla.Target{Severity: "low", Stage: sla.StageBounty, Within: 36 * time.Hour}},
+		{value: "critical:triage", wantErr: true},
+		{value: "acme:critical:triage:24h:extra", wantErr: true},
+		{value: "critical:triage:soon", wantErr: true},
+		{value: "critical:triage:0s", wantErr: true},
+		{value: "critical:disclosure:24h", wantErr: true},
+		{value: ":triage:24h", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := sla.ParseTarget(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTarget() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTarget() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicyWithin(t *testing.T) {
+	policy := sla.NewPolicy([]sla.Target{
+		{Program: "acme", Severity: "critical", Stage: sla.StageTriage, Within: 4 * time.Hour},
+		{Severity: "critical", Stage: sla.StageTriage, Within: 24 * time.Hour},
+		{Severity: "high", Stage: sla.StageTriage, Within: 48 * time.Hour},
+		{Program: "globex", Severity: "low", Stage: sla.StageResolution, Within: 90 * 24 * time.Hour},
+	})
+
+	tests := []struct {
+		name     string
+		program  string
+		severity string
+		stage    sla.Stage
+		want     time.Duration
+		wantOK   bool
+	}{
+		{"program target beats global target", "acme", "critical", sla.StageTriage, 4 * time.Hour, true},
+		{"global target applies to other programs", "globex", "critical", sla.StageTriage, 24 * time.Hour, true},
+		{"global target without program target", "acme", "high", sla.StageTriage, 48 * time.Hour, true},
+		{"program target only applies to its program", "acme", "low", sla.StageResolution, 0, false},
+		{"program target", "globex", "low", sla.StageResolution, 90 * 24 * time.Hour, true},
+		{"no target for stage", "acme", "critical", sla.StageBounty, 0, false},
+		{"no target for severity", "acme", "unknown", sla.StageTriage, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := policy.Within(tt.program, tt.severity, tt.stage)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Within(%q, %q, %q) = %v, %t, want %v, %t", tt.program, tt.severity, tt.stage, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestPolicyWithinGlobalTargetFirst(t *testing.T) {
+	// Program targets take precedence no matter where they are listed
+	policy := sla.NewPolicy([]sla.Target{
+		{Severity: "critical", Stage: sla.StageTriage, Within: 24 * time.Hour},
+		{Program: "acme", Severity: "critical", Stage: sla.StageTriage, Within: 4 * time.Hour},
+	})
+
+	if got, _ := policy.Within("acme", "critical", sla.StageTriage); got != 4*time.Hour {
+		t.Errorf("Within() = %v, want %v", got, 4*time.Hour)
+	}
+}
+
+func TestPolicyEmpty(t *testing.T) {
+	var nilPolicy *sla.Policy
+	tests := []struct {
+		name   string
+		policy *sla.Policy
+		want   bool
+	}{
+		{"nil", nilPolicy, true},
+		{"no targets", sla.NewPolicy(nil), true},
+		{"targets", sla.NewPolicy([]sla.Target{{Severity: "critical", Stage: sla.StageTriage, Within: time.Hour}}), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Empty(); got != tt.want {
+				t.Errorf("Empty() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
@@ -12,309 +12,246 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+// Package types holds the resources of the HackerOne API. Resources are
+// declared through the generic JSON:API types of package jsonapi, which
+// decode relationships and resolve them against the `included` array.
 package types
 
-import "time"
-
-type Assets struct {
-	Data []struct {
-		ID         string `json:"id"`
-		Type       string `json:"type"`
-		Attributes struct {
-			AssetType                  string    `json:"asset_type"`
-			DomainName                 string    `json:"domain_name"`
-			Description                any       `json:"description"`
-			Coverage                   string    `json:"coverage"`
-			MaxSeverity                string    `json:"max_severity"`
-			ConfidentialityRequirement string    `json:"confidentiality_requirement"`
-			IntegrityRequirement       string    `json:"integrity_requirement"`
-			AvailabilityRequirement    string    `json:"availability_requirement"`
-			CreatedAt                  time.Time `json:"created_at"`
-			UpdatedAt                  time.Time `json:"updated_at"`
-			ArchivedAt                 time.Time `json:"archived_at"`
-			Reference                  string    `json:"reference"`
-			State                      string    `json:"state"`
-		} `json:"attributes"`
-		Relationships struct {
-			AssetTags struct {
-				AssetTagsData []struct {
-					ID                      string `json:"id"`
-					Type                    string `json:"type"`
-					AssetTagsDataAttributes struct {
-						Name string `json:"name"`
-					} `json:"attributes"`
-					AssetTagsDataRelationships struct {
-						AssetTagCategory struct {
-							AssetTagCategoryData struct {
-								ID                             string `json:"id"`
-								Type                           string `json:"type"`
-								AssetTagCategoryDataAttributes struct {
-									Name string `json:"name"`
-								} `json:"attributes"`
-							} `json:"data"`
-						} `json:"asset_tag_category"`
-					} `json:"relationships"`
-				} `json:"data"`
-			} `json:"asset_tags"`
-			Programs struct {
-				ProgramsData []struct {
-					ID                     string `json:"id"`
-					Type                   string `json:"type"`
-					ProgramsDataAttributes struct {
-						Handle string `json:"handle"`
-						Name   string `json:"name"`
-					} `json:"attributes"`
-				} `json:"data"`
-			} `json:"programs"`
-			Attachments struct {
-				AttachmentsData []struct {
-					ID                        string `json:"id"`
-					Type                      string `json:"type"`
-					AttachmentsDataAttributes struct {
-						ExpiringURL string    `json:"expiring_url"`
-						CreatedAt   time.Time `json:"created_at"`
-						FileName    string    `json:"file_name"`
-						ContentType string    `json:"content_type"`
-						FileSize    int       `json:"file_size"`
-					} `json:"attributes"`
-				} `json:"data"`
-			} `json:"attachments"`
-		} `json:"relationships"`
-	} `json:"data"`
-	Links struct{} `json:"links"`
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dirsigler/hackerone-exporter/pkg/jsonapi"
+)
+
+// Links holds the JSON:API pagination links returned with every collection
+type Links = jsonapi.Links
+
+// Amount is a monetary value, which the API returns either as a JSON string
+// or as a JSON number
+type Amount float64
+
+// UnmarshalJSON implements json.Unmarshaler
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	value := strings.Trim(string(data), `"`)
+	if value == "" || value == "null" {
+		*a = 0
+		return nil
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("parsing amount %s: %w", data, err)
+	}
+
+	*a = Amount(f)
+	return nil
+}
+
+// ProfilePicture holds the URLs of a user's profile picture by size
+type ProfilePicture struct {
+	Size62  string `json:"62x62"`
+	Size82  string `json:"82x82"`
+	Size110 string `json:"110x110"`
+	Size260 string `json:"260x260"`
+}
+
+// UserAttributes are the attributes of a HackerOne user. Reputation, signal
+// and impact are only returned where the user acts as a hacker.
+type UserAttributes struct {
+	Username         string         `json:"username"`
+	Name             string         `json:"name"`
+	Disabled         bool           `json:"disabled"`
+	CreatedAt        time.Time      `json:"created_at"`
+	ProfilePicture   ProfilePicture `json:"profile_picture"`
+	Reputation       int            `json:"reputation"`
+	Signal           float64        `json:"signal"`
+	Impact           float64        `json:"impact"`
+	Bio              string         `json:"bio"`
+	Website          string         `json:"website"`
+	Location         string         `json:"location"`
+	HackeroneTriager bool           `json:"hackerone_triager"`
+}
+
+type User = jsonapi.Resource[UserAttributes, jsonapi.None]
+
+type AssetAttributes struct {
+	AssetType                  string           `json:"asset_type"`
+	DomainName                 string           `json:"domain_name"`
+	Description                string           `json:"description"`
+	Coverage                   string           `json:"coverage"`
+	MaxSeverity                string           `json:"max_severity"`
+	ConfidentialityRequirement string           `json:"confidentiality_requirement"`
+	IntegrityRequirement       string           `json:"integrity_requirement"`
+	AvailabilityRequirement    string           `json:"availability_requirement"`
+	CreatedAt                  time.Time        `json:"created_at"`
+	UpdatedAt                  time.Time        `json:"updated_at"`
+	ArchivedAt                 jsonapi.NullTime `json:"archived_at"`
+	Reference                  string           `json:"reference"`
+	State                      string           `json:"state"`
+}
+
+type AssetRelationships struct {
+	AssetTags   jsonapi.ToMany[AssetTag]   `json:"asset_tags"`
+	Programs    jsonapi.ToMany[Program]    `json:"programs"`
+	Attachments jsonapi.ToMany[Attachment] `json:"attachments"`
+}
+
+type Asset = jsonapi.Resource[AssetAttributes, AssetRelationships]
+
+type Assets = jsonapi.Document[[]Asset]
+
+type AssetTagAttributes struct {
+	Name string `json:"name"`
+}
+
+type AssetTagRelationships struct {
+	AssetTagCategory jsonapi.ToOne[AssetTagCategory] `json:"asset_tag_category"`
+}
+
+type AssetTag = jsonapi.Resource[AssetTagAttributes, AssetTagRelationships]
+
+type AssetTagCategoryAttributes struct {
+	Name string `json:"name"`
+}
+
+type AssetTagCategory = jsonapi.Resource[AssetTagCategoryAttributes, jsonapi.None]
+
+type AttachmentAttributes struct {
+	ExpiringURL string    `json:"expiring_url"`
+	CreatedAt   time.Time `json:"created_at"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	FileSize    int       `json:"file_size"`
+}
+
+type Attachment = jsonapi.Resource[AttachmentAttributes, jsonapi.None]
+
+type ReportAttributes struct {
+	Title                    string           `json:"title"`
+	State                    string           `json:"state"`
+	CreatedAt                time.Time        `json:"created_at"`
+	SubmittedAt              time.Time        `json:"submitted_at"`
+	VulnerabilityInformation string           `json:"vulnerability_information"`
+	TriagedAt                jsonapi.NullTime `json:"triaged_at"`
+	ClosedAt                 jsonapi.NullTime `json:"closed_at"`
+	LastReporterActivityAt   jsonapi.NullTime `json:"last_reporter_activity_at"`
+	FirstProgramActivityAt   jsonapi.NullTime `json:"first_program_activity_at"`
+	LastProgramActivityAt    jsonapi.NullTime `json:"last_program_activity_at"`
+	BountyAwardedAt          jsonapi.NullTime `json:"bounty_awarded_at"`
+	LastActivityAt           jsonapi.NullTime `json:"last_activity_at"`
+	LastPublicActivityAt     jsonapi.NullTime `json:"last_public_activity_at"`
+	SwagAwardedAt            jsonapi.NullTime `json:"swag_awarded_at"`
+	DisclosedAt              jsonapi.NullTime `json:"disclosed_at"`
+}
+
+type ReportRelationships struct {
+	Reporter        jsonapi.ToOne[User]            `json:"reporter"`
+	Collaborators   jsonapi.ToMany[Collaborator]   `json:"collaborators"`
+	Program         jsonapi.ToOne[Program]         `json:"program"`
+	Weakness        jsonapi.ToOne[Weakness]        `json:"weakness"`
+	StructuredScope jsonapi.ToOne[StructuredScope] `json:"structured_scope"`
+	Severity        jsonapi.ToOne[Severity]        `json:"severity"`
+	Bounties        jsonapi.ToMany[Bounty]         `json:"bounties"`
+}
+
+type Report = jsonapi.Resource[ReportAttributes, ReportRelationships]
+
+type Reports = jsonapi.Document[[]Report]
+
+// Collaborator is a user sharing the bounty of a report
+type Collaborator struct {
+	Weight int  `json:"weight"`
+	User   User `json:"user"`
+}
+
+type SeverityAttributes struct {
+	Rating    string    `json:"rating"`
+	Score     float64   `json:"score"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Severity = jsonapi.Resource[SeverityAttributes, jsonapi.None]
+
+type BountyAttributes struct {
+	Amount             Amount    `json:"amount"`
+	BonusAmount        Amount    `json:"bonus_amount"`
+	AwardedAmount      Amount    `json:"awarded_amount"`
+	AwardedBonusAmount Amount    `json:"awarded_bonus_amount"`
+	AwardedCurrency    string    `json:"awarded_currency"`
+	CreatedAt          time.Time `json:"created_at"`
 }
 
-type Reports struct {
-	Data []struct {
-		ID         string `json:"id"`
-		Type       string `json:"type"`
-		Attributes struct {
-			Title                    string    `json:"title"`
-			State                    string    `json:"state"`
-			CreatedAt                time.Time `json:"created_at"`
-			SubmittedAt              time.Time `json:"submitted_at"`
-			VulnerabilityInformation string    `json:"vulnerability_information"`
-			TriagedAt                any       `json:"triaged_at"`
-			ClosedAt                 any       `json:"closed_at"`
-			LastReporterActivityAt   any       `json:"last_reporter_activity_at"`
-			FirstProgramActivityAt   any       `json:"first_program_activity_at"`
-			LastProgramActivityAt    any       `json:"last_program_activity_at"`
-			BountyAwardedAt          any       `json:"bounty_awarded_at"`
-			LastActivityAt           any       `json:"last_activity_at"`
-			LastPublicActivityAt     any       `json:"last_public_activity_at"`
-			SwagAwardedAt            any       `json:"swag_awarded_at"`
-			DisclosedAt              any       `json:"disclosed_at"`
-		} `json:"attributes,omitempty"`
-		Relationships struct {
-			Reporter struct {
-				Data struct {
-					ID         string `json:"id"`
-					Type       string `json:"type"`
-					Attributes struct {
-						Username       string    `json:"username"`
-						Name           string    `json:"name"`
-						Disabled       bool      `json:"disabled"`
-						CreatedAt      time.Time `json:"created_at"`
-						ProfilePicture struct {
-							Six2X62   string `json:"62x62"`
-							Eight2X82 string `json:"82x82"`
-							One10X110 string `json:"110x110"`
-							Two60X260 string `json:"260x260"`
-						} `json:"profile_picture"`
-					} `json:"attributes"`
-				} `json:"data"`
-			} `json:"reporter"`
-			Collaborators struct {
-				Data []struct {
-					Weight int `json:"weight"`
-					User   struct {
-						ID         string `json:"id"`
-						Type       string `json:"type"`
-						Attributes struct {
-							Username       string    `json:"username"`
-							Name           string    `json:"name"`
-							Disabled       bool      `json:"disabled"`
-							CreatedAt      time.Time `json:"created_at"`
-							ProfilePicture struct {
-								Six2X62   string `json:"62x62"`
-								Eight2X82 string `json:"82x82"`
-								One10X110 string `json:"110x110"`
-								Two60X260 string `json:"260x260"`
-							} `json:"profile_picture"`
-							Reputation int `json:"reputation"`
-							Signal     int `json:"signal"`
-							Impact     int `json:"impact"`
-						} `json:"attributes"`
-					} `json:"user"`
-				} `json:"data"`
-			} `json:"collaborators"`
-			Program struct {
-				Data struct {
-					ID         string `json:"id"`
-					Type       string `json:"type"`
-					Attributes struct {
-						Handle    string    `json:"handle"`
-						CreatedAt time.Time `json:"created_at"`
-						UpdatedAt time.Time `json:"updated_at"`
-					} `json:"attributes"`
-				} `json:"data"`
-			} `json:"program"`
-			Weakness struct {
-				Data struct {
-					ID         string `json:"id"`
-					Type       string `json:"type"`
-					Attributes struct {
-						Name        string    `json:"name"`
-						Description string    `json:"description"`
-						ExternalID  string    `json:"external_id"`
-						CreatedAt   time.Time `json:"created_at"`
-					} `json:"attributes"`
-				} `json:"data"`
-			} `json:"weakness"`
-			Bounties struct {
-				Data []any `json:"data"`
-			} `json:"bounties"`
-		} `json:"relationships,omitempty"`
-	} `json:"data"`
-	Links struct {
-		Self string `json:"self"`
-		Next string `json:"next"`
-		Last string `json:"last"`
-	} `json:"links"`
+type Bounty = jsonapi.Resource[BountyAttributes, jsonapi.None]
+
+type ProgramAttributes struct {
+	Handle    string    `json:"handle"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
-type Programs struct {
-	Data []struct {
-		ID         string `json:"id"`
-		Type       string `json:"type"`
-		Attributes struct {
-			Handle    string    `json:"handle"`
-			CreatedAt time.Time `json:"created_at"`
-			UpdatedAt time.Time `json:"updated_at"`
-		} `json:"attributes"`
-	} `json:"data"`
-	Links struct{} `json:"links"`
+type Program = jsonapi.Resource[ProgramAttributes, jsonapi.None]
+
+type Programs = jsonapi.Document[[]Program]
+
+type InvitedHackerAttributes struct {
+	State       string           `json:"state"`
+	CreatedAt   time.Time        `json:"created_at"`
+	ViewedAt    jsonapi.NullTime `json:"viewed_at"`
+	AcceptedAt  jsonapi.NullTime `json:"accepted_at"`
+	ExpiresAt   jsonapi.NullTime `json:"expires_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+	RejectedAt  jsonapi.NullTime `json:"rejected_at"`
+	CancelledAt jsonapi.NullTime `json:"cancelled_at"`
 }
 
-type InvitedHackers struct {
-	Data []struct {
-		ID         string `json:"id"`
-		Type       string `json:"type"`
-		Attributes struct {
-			State       string    `json:"state"`
-			CreatedAt   time.Time `json:"created_at"`
-			ViewedAt    time.Time `json:"viewed_at"`
-			AcceptedAt  time.Time `json:"accepted_at"`
-			ExpiresAt   any       `json:"expires_at"`
-			UpdatedAt   time.Time `json:"updated_at"`
-			RejectedAt  any       `json:"rejected_at"`
-			CancelledAt any       `json:"cancelled_at"`
-		} `json:"attributes"`
-		Relationships struct {
-			Recipient struct {
-				ID                  string `json:"id"`
-				Type                string `json:"type"`
-				RecipientAttributes struct {
-					Username       string    `json:"username"`
-					Name           string    `json:"name"`
-					Disabled       bool      `json:"disabled"`
-					CreatedAt      time.Time `json:"created_at"`
-					ProfilePicture struct {
-						Six2X62   string `json:"62x62"`
-						Eight2X82 string `json:"82x82"`
-						One10X110 string `json:"110x110"`
-						Two60X260 string `json:"260x260"`
-					} `json:"profile_picture"`
-					Signal           any    `json:"signal"`
-					Impact           any    `json:"impact"`
-					Reputation       any    `json:"reputation"`
-					Bio              string `json:"bio"`
-					Website          string `json:"website"`
-					Location         string `json:"location"`
-					HackeroneTriager bool   `json:"hackerone_triager"`
-				} `json:"attributes"`
-			} `json:"recipient"`
-			InvitedBy struct {
-				ID                  string `json:"id"`
-				Type                string `json:"type"`
-				InvitedByAttributes struct {
-					Username                          string    `json:"username"`
-					Name                              string    `json:"name"`
-					Disabled                          bool      `json:"disabled"`
-					CreatedAt                         time.Time `json:"created_at"`
-					InvitedByAttributesProfilePicture struct {
-						Six2X62   string `json:"62x62"`
-						Eight2X82 string `json:"82x82"`
-						One10X110 string `json:"110x110"`
-						Two60X260 string `json:"260x260"`
-					} `json:"profile_picture"`
-					Signal           int    `json:"signal"`
-					Impact           int    `json:"impact"`
-					Reputation       int    `json:"reputation"`
-					Bio              string `json:"bio"`
-					Website          string `json:"website"`
-					Location         string `json:"location"`
-					HackeroneTriager bool   `json:"hackerone_triager"`
-				} `json:"attributes"`
-			} `json:"invited_by"`
-		} `json:"relationships"`
-	} `json:"data"`
-	Links struct{} `json:"links"`
+type InvitedHackerRelationships struct {
+	Recipient jsonapi.ToOne[User] `json:"recipient"`
+	InvitedBy jsonapi.ToOne[User] `json:"invited_by"`
 }
 
-type Weaknesses struct {
-	Data []struct {
-		ID         string `json:"id"`
-		Type       string `json:"type"`
-		Attributes struct {
-			Name        string    `json:"name"`
-			Description string    `json:"description"`
-			CreatedAt   time.Time `json:"created_at"`
-			ExternalID  string    `json:"external_id"`
-		} `json:"attributes"`
-	} `json:"data"`
-	Links struct{} `json:"links"`
+type InvitedHacker = jsonapi.Resource[InvitedHackerAttributes, InvitedHackerRelationships]
+
+type InvitedHackers = jsonapi.Document[[]InvitedHacker]
+
+type WeaknessAttributes struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExternalID  string    `json:"external_id"`
 }
 
-type StructuredScopes struct {
-	Data []struct {
-		ID         string `json:"id"`
-		Type       string `json:"type"`
-		Attributes struct {
-			AssetIdentifier            string    `json:"asset_identifier"`
-			AssetType                  string    `json:"asset_type"`
-			ConfidentialityRequirement string    `json:"confidentiality_requirement"`
-			IntegrityRequirement       string    `json:"integrity_requirement"`
-			AvailabilityRequirement    string    `json:"availability_requirement"`
-			MaxSeverity                string    `json:"max_severity"`
-			CreatedAt                  time.Time `json:"created_at"`
-			UpdatedAt                  time.Time `json:"updated_at"`
-			Instruction                any       `json:"instruction"`
-			EligibleForBounty          bool      `json:"eligible_for_bounty"`
-			EligibleForSubmission      bool      `json:"eligible_for_submission"`
-			Reference                  string    `json:"reference"`
-		} `json:"attributes"`
-	} `json:"data"`
-	Links struct{} `json:"links"`
+type Weakness = jsonapi.Resource[WeaknessAttributes, jsonapi.None]
+
+type Weaknesses = jsonapi.Document[[]Weakness]
+
+type StructuredScopeAttributes struct {
+	AssetIdentifier            string    `json:"asset_identifier"`
+	AssetType                  string    `json:"asset_type"`
+	ConfidentialityRequirement string    `json:"confidentiality_requirement"`
+	IntegrityRequirement       string    `json:"integrity_requirement"`
+	AvailabilityRequirement    string    `json:"availability_requirement"`
+	MaxSeverity                string    `json:"max_severity"`
+	CreatedAt                  time.Time `json:"created_at"`
+	UpdatedAt                  time.Time `json:"updated_at"`
+	Instruction                string    `json:"instruction"`
+	EligibleForBounty          bool      `json:"eligible_for_bounty"`
+	EligibleForSubmission      bool      `json:"eligible_for_submission"`
+	Reference                  string    `json:"reference"`
 }
 
-type Reporters struct {
-	Data []struct {
-		ID         string `json:"id"`
-		Type       string `json:"type"`
-		Attributes struct {
-			Username       string    `json:"username"`
-			Name           string    `json:"name"`
-			Disabled       bool      `json:"disabled"`
-			CreatedAt      time.Time `json:"created_at"`
-			ProfilePicture struct {
-				Six2X62   string `json:"62x62"`
-				Eight2X82 string `json:"82x82"`
-				One10X110 string `json:"110x110"`
-				Two60X260 string `json:"260x260"`
-			} `json:"profile_picture"`
-			Reputation int `json:"reputation"`
-		} `json:"attributes"`
-	} `json:"data"`
-	Links struct{} `json:"links"`
+type StructuredScope = jsonapi.Resource[StructuredScopeAttributes, jsonapi.None]
+
+type StructuredScopes = jsonapi.Document[[]StructuredScope]
+
+// Reporters are the users who submitted reports to a program
+type Reporters = jsonapi.Document[[]User]
+
+type ProgramBalanceAttributes struct {
+	Balance Amount `json:"balance"`
 }
+
+type ProgramBalance = jsonapi.Document[jsonapi.Resource[ProgramBalanceAttributes, jsonapi.None]]